@@ -0,0 +1,155 @@
+// Package agreement computes inter-rater agreement for nominal labels.
+//
+// Ratings are given as a matrix of counts: one row per item, one column per
+// category, each cell holding how many raters put the item in that category.
+// Items may have different numbers of raters; items with fewer than two
+// ratings carry no information about agreement and are ignored.
+package agreement
+
+// ItemAgreement is the proportion of agreeing rater pairs for one item, the
+// P_i term of Fleiss' kappa. It is 0 when the item has fewer than two ratings.
+func ItemAgreement(counts []int) float64 {
+	n := 0
+	sumSquares := 0
+	for _, c := range counts {
+		n += c
+		sumSquares += c * c
+	}
+	if n < 2 {
+		return 0
+	}
+	return float64(sumSquares-n) / float64(n*(n-1))
+}
+
+// ExpectedAgreement is the agreement expected by chance, the P_e term of
+// Fleiss' kappa, from the overall proportion of each category.
+func ExpectedAgreement(counts [][]int) float64 {
+	totals, n := categoryTotals(counts)
+	if n == 0 {
+		return 0
+	}
+	expected := 0.0
+	for _, t := range totals {
+		p := float64(t) / float64(n)
+		expected += p * p
+	}
+	return expected
+}
+
+// FleissKappa measures agreement across all items, corrected for chance.
+// It is 1 for perfect agreement, 0 for chance-level agreement and negative
+// below chance. Items with fewer than two ratings are skipped.
+func FleissKappa(counts [][]int) float64 {
+	rated := make([][]int, 0, len(counts))
+	for _, item := range counts {
+		if sum(item) >= 2 {
+			rated = append(rated, item)
+		}
+	}
+	if len(rated) == 0 {
+		return 0
+	}
+
+	observed := 0.0
+	for _, item := range rated {
+		observed += ItemAgreement(item)
+	}
+	observed /= float64(len(rated))
+
+	return Kappa(observed, ExpectedAgreement(rated))
+}
+
+// Kappa corrects an observed agreement for the agreement expected by chance
+func Kappa(observed, expected float64) float64 {
+	if expected >= 1 {
+		if observed >= 1 {
+			return 1
+		}
+		return 0
+	}
+	return (observed - expected) / (1 - expected)
+}
+
+// KrippendorffAlpha measures agreement across all items for nominal data
+// using the coincidence matrix formulation. Items with fewer than two
+// ratings are skipped.
+func KrippendorffAlpha(counts [][]int) float64 {
+	categories := 0
+	for _, item := range counts {
+		if len(item) > categories {
+			categories = len(item)
+		}
+	}
+
+	// Coincidences within an item are weighted by 1 / (m - 1) for m ratings
+	coincidences := make([][]float64, categories)
+	for i := range coincidences {
+		coincidences[i] = make([]float64, categories)
+	}
+	for _, item := range counts {
+		m := sum(item)
+		if m < 2 {
+			continue
+		}
+		for c, nc := range item {
+			for k, nk := range item {
+				pairs := nc * nk
+				if c == k {
+					pairs = nc * (nc - 1)
+				}
+				coincidences[c][k] += float64(pairs) / float64(m-1)
+			}
+		}
+	}
+
+	marginals := make([]float64, categories)
+	n := 0.0
+	for c := range coincidences {
+		for k := range coincidences[c] {
+			marginals[c] += coincidences[c][k]
+		}
+		n += marginals[c]
+	}
+	if n <= 1 {
+		return 0
+	}
+
+	disagreement := 0.0
+	expected := 0.0
+	for c := 0; c < categories; c++ {
+		for k := 0; k < categories; k++ {
+			if c == k {
+				continue
+			}
+			disagreement += coincidences[c][k]
+			expected += marginals[c] * marginals[k]
+		}
+	}
+	if expected == 0 {
+		return 1
+	}
+	return 1 - (n-1)*disagreement/expected
+}
+
+func categoryTotals(counts [][]int) ([]int, int) {
+	var totals []int
+	n := 0
+	for _, item := range counts {
+		for c, count := range item {
+			for len(totals) <= c {
+				totals = append(totals, 0)
+			}
+			totals[c] += count
+			n += count
+		}
+	}
+	return totals, n
+}
+
+func sum(counts []int) int {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
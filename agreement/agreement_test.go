@@ -0,0 +1,105 @@
+package agreement
+
+import (
+	"math"
+	"testing"
+)
+
+const tolerance = 0.001
+
+// fleissExample is the worked example of Fleiss (1971): 10 items rated by 14 raters into 5 categories
+var fleissExample = [][]int{
+	{0, 0, 0, 0, 14},
+	{0, 2, 6, 4, 2},
+	{0, 0, 3, 5, 6},
+	{0, 3, 9, 2, 0},
+	{2, 2, 8, 1, 1},
+	{7, 7, 0, 0, 0},
+	{3, 2, 6, 3, 0},
+	{2, 5, 3, 2, 2},
+	{6, 5, 2, 1, 0},
+	{0, 2, 2, 3, 7},
+}
+
+// krippendorffExample is the nominal example of Krippendorff (2011), "Computing Krippendorff's
+// Alpha-Reliability": 12 units rated by up to 4 observers into 5 values, with missing ratings
+var krippendorffExample = [][]int{
+	{3, 0, 0, 0, 0},
+	{0, 3, 1, 0, 0},
+	{0, 0, 4, 0, 0},
+	{0, 0, 4, 0, 0},
+	{0, 4, 0, 0, 0},
+	{1, 1, 1, 1, 0},
+	{0, 0, 0, 4, 0},
+	{3, 1, 0, 0, 0},
+	{0, 4, 0, 0, 0},
+	{0, 0, 0, 0, 3},
+	{2, 0, 0, 0, 0},
+	{0, 0, 1, 0, 0},
+}
+
+func TestItemAgreement(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		want   float64
+	}{
+		{"unanimous", []int{0, 4, 0}, 1},
+		{"split pair", []int{1, 1}, 0},
+		{"two of three", []int{2, 1}, 1.0 / 3},
+		{"fleiss item 2", []int{0, 2, 6, 4, 2}, 0.253},
+		{"single rating", []int{0, 1}, 0},
+		{"no ratings", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := ItemAgreement(tt.counts); math.Abs(got-tt.want) > tolerance {
+			t.Errorf("%s: ItemAgreement(%v) = %.4f, want %.4f", tt.name, tt.counts, got, tt.want)
+		}
+	}
+}
+
+func TestFleissKappa(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts [][]int
+		want   float64
+	}{
+		{"fleiss example", fleissExample, 0.210},
+		{"perfect agreement", [][]int{{3, 0}, {0, 3}, {3, 0}}, 1},
+		{"perfect agreement on one category", [][]int{{3, 0}, {2, 0}}, 1},
+		{"chance agreement", [][]int{{2, 0}, {0, 2}, {1, 1}, {1, 1}}, 0},
+		{"below chance", [][]int{{1, 1}, {1, 1}}, -1},
+		{"single ratings are skipped", [][]int{{2, 0}, {0, 2}, {1, 1}, {1, 1}, {1, 0}, {0, 1}}, 0},
+		{"only single ratings", [][]int{{1, 0}, {0, 1}}, 0},
+		{"no items", nil, 0},
+	}
+	for _, tt := range tests {
+		got := FleissKappa(tt.counts)
+		if math.IsNaN(got) || math.Abs(got-tt.want) > tolerance {
+			t.Errorf("%s: FleissKappa = %.4f, want %.4f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKrippendorffAlpha(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts [][]int
+		want   float64
+	}{
+		{"krippendorff example", krippendorffExample, 0.743},
+		{"perfect agreement", [][]int{{3, 0}, {0, 3}, {3, 0}}, 1},
+		{"perfect agreement on one category", [][]int{{3, 0}, {2, 0}}, 1},
+		// Alpha corrects for the small sample, so it is slightly above 0 where kappa is 0
+		{"chance agreement", [][]int{{2, 0}, {0, 2}, {1, 1}, {1, 1}}, 0.125},
+		{"single ratings are skipped", [][]int{{2, 0}, {0, 2}, {1, 1}, {1, 1}, {1, 0}, {0, 1}}, 0.125},
+		{"only single ratings", [][]int{{1, 0}, {0, 1}}, 0},
+		{"no items", nil, 0},
+	}
+	for _, tt := range tests {
+		got := KrippendorffAlpha(tt.counts)
+		if math.IsNaN(got) || math.Abs(got-tt.want) > tolerance {
+			t.Errorf("%s: KrippendorffAlpha = %.4f, want %.4f", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package classifier puts sentiment models behind one interface, so the built-in lexicon
+// classifier and models hosted elsewhere can be scored and compared on the same dataset.
+package classifier
+
+import (
+	"context"
+	"fmt"
+	"vibecheck/sentiment"
+)
+
+// Prediction is the label and score a classifier assigns to a text
+type Prediction struct {
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// Classifier labels texts with a sentiment model. Classify receives at most BatchSize texts
+// and returns one prediction per text, in order.
+type Classifier interface {
+	Name() string
+	Version() string
+	BatchSize() int
+	Classify(ctx context.Context, texts []string) ([]Prediction, error)
+}
+
+// ClassifyAll classifies any number of texts, in batches the classifier accepts
+func ClassifyAll(ctx context.Context, c Classifier, texts []string) ([]Prediction, error) {
+	size := c.BatchSize()
+	if size < 1 {
+		size = len(texts)
+	}
+	predictions := make([]Prediction, 0, len(texts))
+	for start := 0; start < len(texts); start += size {
+		end := min(start+size, len(texts))
+		batch, err := c.Classify(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) != end-start {
+			return nil, fmt.Errorf("classifier %s returned %d predictions for %d texts", c.Name(), len(batch), end-start)
+		}
+		predictions = append(predictions, batch...)
+	}
+	return predictions, nil
+}
+
+// Lexicon adapts the built-in sentiment classifier
+type Lexicon struct{}
+
+func (Lexicon) Name() string {
+	return sentiment.Model
+}
+
+func (Lexicon) Version() string {
+	return sentiment.Version
+}
+
+func (Lexicon) BatchSize() int {
+	return 0
+}
+
+func (Lexicon) Classify(ctx context.Context, texts []string) ([]Prediction, error) {
+	predictions := make([]Prediction, len(texts))
+	for i, text := range texts {
+		prediction := sentiment.Classify(text)
+		predictions[i] = Prediction{Label: prediction.Label, Score: prediction.Compound}
+	}
+	return predictions, nil
+}
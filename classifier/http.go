@@ -0,0 +1,113 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"vibecheck/sentiment"
+)
+
+// Request is the body POSTed to an HTTP classifier
+type Request struct {
+	Model string   `json:"model"`
+	Texts []string `json:"texts"`
+}
+
+// Response is the body an HTTP classifier answers with. Version, when set, must match the
+// configured version so predictions are never stored under the wrong one.
+type Response struct {
+	Version     string       `json:"version,omitempty"`
+	Predictions []Prediction `json:"predictions"`
+}
+
+// HTTP classifies texts by POSTing them as JSON to a model server
+type HTTP struct {
+	name      string
+	version   string
+	url       string
+	batchSize int
+	client    *http.Client
+}
+
+// NewHTTP creates an adapter for the model server at url
+func NewHTTP(name, version, url string, timeout time.Duration, batchSize int) *HTTP {
+	return &HTTP{name: name, version: version, url: url, batchSize: batchSize, client: &http.Client{Timeout: timeout}}
+}
+
+func (h *HTTP) Name() string {
+	return h.name
+}
+
+func (h *HTTP) Version() string {
+	return h.version
+}
+
+func (h *HTTP) BatchSize() int {
+	return h.batchSize
+}
+
+func (h *HTTP) Classify(ctx context.Context, texts []string) ([]Prediction, error) {
+	body, err := json.Marshal(Request{Model: h.name, Texts: texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return nil, fmt.Errorf("classifier %s responded %d: %s", h.name, res.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var response Response
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("classifier %s: %w", h.name, err)
+	}
+	if response.Version != "" && response.Version != h.version {
+		return nil, fmt.Errorf("classifier %s is version %s, expected %s", h.name, response.Version, h.version)
+	}
+	if len(response.Predictions) != len(texts) {
+		return nil, fmt.Errorf("classifier %s returned %d predictions for %d texts", h.name, len(response.Predictions), len(texts))
+	}
+	for _, prediction := range response.Predictions {
+		if prediction.Label != sentiment.Positive && prediction.Label != sentiment.Negative && prediction.Label != sentiment.Neutral {
+			return nil, fmt.Errorf("classifier %s returned invalid label %q", h.name, prediction.Label)
+		}
+	}
+	return response.Predictions, nil
+}
+
+// StubHandler serves the HTTP classifier protocol with the given classifier, as a stand-in
+// for a model server when exercising the adapter locally or in tests
+func StubHandler(version string, c Classifier) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		predictions, err := ClassifyAll(r.Context(), c, request.Texts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Response{Version: version, Predictions: predictions})
+	})
+}
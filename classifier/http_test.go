@@ -0,0 +1,167 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"vibecheck/sentiment"
+)
+
+// fixed labels every text with the same prediction
+type fixed struct {
+	label string
+}
+
+func (fixed) Name() string    { return "fixed" }
+func (fixed) Version() string { return "1" }
+func (fixed) BatchSize() int  { return 0 }
+
+func (f fixed) Classify(ctx context.Context, texts []string) ([]Prediction, error) {
+	predictions := make([]Prediction, len(texts))
+	for i := range predictions {
+		predictions[i] = Prediction{Label: f.label, Score: 0.5}
+	}
+	return predictions, nil
+}
+
+// batchRecorder records the size of each request the stub receives
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []int
+	next    http.Handler
+}
+
+func (b *batchRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var request Request
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	b.mu.Lock()
+	b.batches = append(b.batches, len(request.Texts))
+	b.mu.Unlock()
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	b.next.ServeHTTP(w, r)
+}
+
+func TestClassifyAllBatches(t *testing.T) {
+	texts := []string{"I love this", "I hate this", "it is a chair", "what a great day", "terrible service"}
+	want, err := ClassifyAll(context.Background(), Lexicon{}, texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		batchSize int
+		batches   []int
+	}{
+		{batchSize: 1, batches: []int{1, 1, 1, 1, 1}},
+		{batchSize: 2, batches: []int{2, 2, 1}},
+		{batchSize: 0, batches: []int{5}},
+	}
+	for _, tt := range tests {
+		recorder := &batchRecorder{next: StubHandler(sentiment.Version, Lexicon{})}
+		server := httptest.NewServer(recorder)
+		c := NewHTTP("stub", sentiment.Version, server.URL, time.Second, tt.batchSize)
+
+		got, err := ClassifyAll(context.Background(), c, texts)
+		server.Close()
+		if err != nil {
+			t.Fatalf("batch size %d: %v", tt.batchSize, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("batch size %d: got %d predictions, want %d", tt.batchSize, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("batch size %d: prediction %d = %+v, want %+v", tt.batchSize, i, got[i], want[i])
+			}
+		}
+		if len(recorder.batches) != len(tt.batches) {
+			t.Fatalf("batch size %d: sent batches %v, want %v", tt.batchSize, recorder.batches, tt.batches)
+		}
+		for i := range tt.batches {
+			if recorder.batches[i] != tt.batches[i] {
+				t.Errorf("batch size %d: sent batches %v, want %v", tt.batchSize, recorder.batches, tt.batches)
+				break
+			}
+		}
+	}
+}
+
+func TestHTTPErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.Handler
+		timeout time.Duration
+		want    string
+	}{
+		{
+			name:    "version mismatch",
+			handler: StubHandler("2", Lexicon{}),
+			want:    "is version 2, expected 1",
+		},
+		{
+			name:    "invalid label",
+			handler: StubHandler("1", fixed{label: "happy"}),
+			want:    `invalid label "happy"`,
+		},
+		{
+			name: "wrong prediction count",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(Response{Predictions: []Prediction{{Label: sentiment.Neutral}}})
+			}),
+			want: "returned 1 predictions for 2 texts",
+		},
+		{
+			name: "non-200 response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "model overloaded", http.StatusServiceUnavailable)
+			}),
+			want: "responded 503: model overloaded",
+		},
+		{
+			name: "timeout",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(500 * time.Millisecond):
+				case <-r.Context().Done():
+				}
+			}),
+			timeout: 50 * time.Millisecond,
+			want:    "Client.Timeout exceeded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = time.Second
+			}
+			c := NewHTTP("stub", "1", server.URL, timeout, 0)
+
+			_, err := ClassifyAll(context.Background(), c, []string{"I love this", "I hate this"})
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
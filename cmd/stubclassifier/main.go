@@ -0,0 +1,20 @@
+// Command stubclassifier serves the HTTP classifier protocol backed by the built-in lexicon
+// classifier, to exercise the HTTP adapter without a real model server.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"vibecheck/classifier"
+)
+
+func main() {
+	addr := flag.String("addr", ":9100", "address to listen on")
+	version := flag.String("version", "1", "model version to report")
+	flag.Parse()
+
+	http.Handle("/classify", classifier.StubHandler(*version, classifier.Lexicon{}))
+	log.Printf("Stub classifier listening on %s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
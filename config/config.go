@@ -1,8 +1,14 @@
 package config
 
 import (
+	"fmt"
+	"log"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"vibecheck/models"
 )
 
 type Config struct {
@@ -19,8 +25,87 @@ type Config struct {
 		Password string
 		DB       int
 	}
-	ServicePort string
-	ListPerPage int
+	Daily struct {
+		Size       int
+		Seed       string
+		RepeatDays int
+	}
+	Round struct {
+		DefaultSize      int
+		MaxSize          int
+		DefaultTimeLimit int
+		MaxTimeLimit     int
+	}
+	Rooms struct {
+		DefaultSize      int
+		MaxSize          int
+		DefaultCountdown int
+		MaxCountdown     int
+		TTL              time.Duration
+	}
+	Webhooks struct {
+		PollInterval time.Duration
+		Timeout      time.Duration
+		MaxAttempts  int
+		BackoffBase  time.Duration
+		BackoffMax   time.Duration
+	}
+	Outbox struct {
+		Sink         string
+		Stream       string
+		StreamLength int64
+		URL          string
+		Timeout      time.Duration
+		PollInterval time.Duration
+		Retention    time.Duration
+		MaxAttempts  int
+		BackoffBase  time.Duration
+		BackoffMax   time.Duration
+	}
+	Events struct {
+		Retention int64
+	}
+	Quiz struct {
+		SeenResetWindow time.Duration
+		Sampling        string
+		LabelWeights    map[string]float64
+	}
+	Consensus struct {
+		Rule         string
+		MinVotes     int
+		MinAgreement float64
+	}
+	Review struct {
+		ScanInterval time.Duration
+		MinAttempts  int
+		Threshold    float64
+	}
+	Safety struct {
+		Blocklist  []string
+		ReviewList []string
+		MinLength  int
+		MaxLength  int
+	}
+	Duplicates struct {
+		MaxDistance int
+		NearAction  string
+	}
+	Classifier struct {
+		Name      string
+		Version   string
+		URL       string
+		Timeout   time.Duration
+		BatchSize int
+	}
+	GraphQL struct {
+		MaxDepth      int
+		MaxComplexity int
+	}
+	AdminToken        string
+	SchedulerInterval time.Duration
+	ServicePort       string
+	GRPCPort          string
+	ListPerPage       int
 }
 
 func LoadConfig() Config {
@@ -45,12 +130,162 @@ func LoadConfig() Config {
 	config.Redis.DB = redisDB
 	config.ListPerPage = listPerPage
 	config.ServicePort = getEnv("API_INTERNAL_PORT", "9000")
+	config.GRPCPort = getEnv("GRPC_INTERNAL_PORT", "9090")
+	config.Daily.Size = getEnvInt("DAILY_CHALLENGE_SIZE", 5)
+	config.Daily.Seed = getEnv("DAILY_CHALLENGE_SEED", "vibecheck")
+	config.Daily.RepeatDays = getEnvInt("DAILY_CHALLENGE_REPEAT_DAYS", 30)
+	config.Round.DefaultSize = getEnvInt("ROUND_DEFAULT_SIZE", 10)
+	config.Round.MaxSize = getEnvInt("ROUND_MAX_SIZE", 50)
+	config.Round.DefaultTimeLimit = getEnvInt("ROUND_DEFAULT_TIME_LIMIT", 120)
+	config.Round.MaxTimeLimit = getEnvInt("ROUND_MAX_TIME_LIMIT", 1800)
+	config.Rooms.DefaultSize = getEnvInt("ROOM_DEFAULT_SIZE", 10)
+	config.Rooms.MaxSize = getEnvInt("ROOM_MAX_SIZE", 50)
+	config.Rooms.DefaultCountdown = getEnvInt("ROOM_DEFAULT_COUNTDOWN", 20)
+	config.Rooms.MaxCountdown = getEnvInt("ROOM_MAX_COUNTDOWN", 300)
+	config.Rooms.TTL = getEnvDuration("ROOM_TTL", 6*time.Hour)
+	config.Webhooks.PollInterval = getEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second)
+	config.Webhooks.Timeout = getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+	config.Webhooks.MaxAttempts = getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8)
+	config.Webhooks.BackoffBase = getEnvDuration("WEBHOOK_BACKOFF_BASE", 30*time.Second)
+	config.Webhooks.BackoffMax = getEnvDuration("WEBHOOK_BACKOFF_MAX", 6*time.Hour)
+	config.Outbox.Sink = getEnv("OUTBOX_SINK", "redis")
+	config.Outbox.Stream = getEnv("OUTBOX_STREAM", "outbox")
+	config.Outbox.StreamLength = int64(getEnvInt("OUTBOX_STREAM_LENGTH", 100000))
+	config.Outbox.URL = getEnv("OUTBOX_URL", "")
+	config.Outbox.Timeout = getEnvDuration("OUTBOX_TIMEOUT", 10*time.Second)
+	config.Outbox.PollInterval = getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second)
+	config.Outbox.Retention = getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour)
+	config.Outbox.MaxAttempts = getEnvInt("OUTBOX_MAX_ATTEMPTS", 20)
+	config.Outbox.BackoffBase = getEnvDuration("OUTBOX_BACKOFF_BASE", time.Second)
+	config.Outbox.BackoffMax = getEnvDuration("OUTBOX_BACKOFF_MAX", 5*time.Minute)
+	config.Events.Retention = int64(getEnvInt("EVENTS_RETENTION", 1000))
+	config.Quiz.SeenResetWindow = getEnvDuration("QUIZ_SEEN_RESET_WINDOW", 0)
+	config.Quiz.Sampling = getEnv("QUIZ_SAMPLING", "stratified")
+	config.Quiz.LabelWeights = getEnvWeights("QUIZ_LABEL_WEIGHTS", models.Labels)
+	config.Consensus.Rule = getEnvChoice("CONSENSUS_RULE", "majority", "majority", "weighted")
+	config.Consensus.MinVotes = getEnvInt("CONSENSUS_MIN_VOTES", 3)
+	config.Consensus.MinAgreement = getEnvFloat("CONSENSUS_MIN_AGREEMENT", 0.6)
+	config.Review.ScanInterval = getEnvDuration("REVIEW_SCAN_INTERVAL", time.Hour)
+	config.Review.MinAttempts = getEnvInt("REVIEW_MIN_ATTEMPTS", 10)
+	config.Review.Threshold = getEnvFloat("REVIEW_DISAGREEMENT_THRESHOLD", 0.6)
+	config.Safety.Blocklist = getEnvList("SAFETY_BLOCKLIST")
+	config.Safety.ReviewList = getEnvList("SAFETY_REVIEWLIST")
+	config.Safety.MinLength = getEnvInt("SAFETY_MIN_LENGTH", 3)
+	config.Safety.MaxLength = getEnvInt("SAFETY_MAX_LENGTH", 280)
+	config.Duplicates.MaxDistance = getEnvInt("DUPLICATE_MAX_DISTANCE", 6)
+	config.Duplicates.NearAction = getEnv("DUPLICATE_NEAR_ACTION", "warn")
+	config.Classifier.Name = getEnv("CLASSIFIER_NAME", "external")
+	config.Classifier.Version = getEnv("CLASSIFIER_VERSION", "1")
+	config.Classifier.URL = getEnv("CLASSIFIER_URL", "")
+	config.Classifier.Timeout = getEnvDuration("CLASSIFIER_TIMEOUT", 10*time.Second)
+	config.Classifier.BatchSize = getEnvInt("CLASSIFIER_BATCH_SIZE", 32)
+	config.GraphQL.MaxDepth = getEnvInt("GRAPHQL_MAX_DEPTH", 8)
+	config.GraphQL.MaxComplexity = getEnvInt("GRAPHQL_MAX_COMPLEXITY", 1000)
+	config.AdminToken = getEnv("ADMIN_TOKEN", "")
+	config.SchedulerInterval = getEnvDuration("SCHEDULER_INTERVAL", time.Minute)
+
+	// A daily challenge has at least one problem
+	if config.Daily.Size < 1 {
+		config.Daily.Size = 1
+	}
 	return config
 }
 
+// String formats the config for logging, with its passwords and tokens masked
+func (c Config) String() string {
+	type plain Config
+	masked := plain(c)
+	masked.DB.Password = mask(masked.DB.Password)
+	masked.Redis.Password = mask(masked.Redis.Password)
+	masked.AdminToken = mask(masked.AdminToken)
+	return fmt.Sprintf("%+v", masked)
+}
+
+// mask hides a secret, showing only whether it is set
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "***"
+}
+
 func getEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
 	}
 	return fallback
 }
+
+// getEnvChoice returns the value of key, refusing to start with a value that is not allowed
+func getEnvChoice(key, fallback string, allowed ...string) string {
+	value := getEnv(key, fallback)
+	if !slices.Contains(allowed, value) {
+		log.Fatalf("Invalid %s %q, expected one of: %s\n", key, value, strings.Join(allowed, ", "))
+	}
+	return value
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(fallback)))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, fallback.String()))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, strconv.FormatFloat(fallback, 'f', -1, 64)), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvWeights parses a comma-separated list of name:weight pairs for the given names,
+// skipping malformed pairs and unknown names. A list that gives no name any weight is
+// ignored, so callers fall back to their default weights.
+func getEnvWeights(key string, names []string) map[string]float64 {
+	weights := map[string]float64{}
+	pairs := getEnvList(key)
+	total := 0.0
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if !slices.Contains(names, name) {
+			log.Printf("Ignoring unknown name %q in %s, expected one of: %s\n", name, key, strings.Join(names, ", "))
+			continue
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			continue
+		}
+		weights[name] = weight
+		total += weight
+	}
+	if len(pairs) > 0 && total == 0 {
+		log.Printf("%s gives no weight to any of: %s; ignoring it\n", key, strings.Join(names, ", "))
+		return map[string]float64{}
+	}
+	return weights
+}
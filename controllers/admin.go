@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// adminModerator is recorded as who took a decision on a curator route: the admin token
+// is shared, so it does not tell curators apart
+const adminModerator = "admin"
+
+// privileged reports whether the caller presented the admin token as a bearer token.
+// Nobody is privileged while no admin token is configured.
+func (vc *vibecheckController) privileged(c *gin.Context) bool {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || vc.adminToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(vc.adminToken)) == 1
+}
+
+// RequireAdmin lets only callers presenting the admin token through to curator routes,
+// which expose gold answers or change the dataset
+func (vc *vibecheckController) RequireAdmin(c *gin.Context) {
+	if _, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing admin token"})
+		return
+	}
+	if !vc.privileged(c) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invalid admin token"})
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetClassifiers lists the registered classifiers
+func (vc *vibecheckController) GetClassifiers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"message": "Classifiers retrieved successfully", "classifiers": vc.vibecheckService.GetClassifiers()})
+}
+
+// ScorePredictions classifies every tweet a classifier has not scored yet
+func (vc *vibecheckController) ScorePredictions(c *gin.Context) {
+	scored, err := vc.vibecheckService.ScorePredictions(c.Param("name"))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownClassifier) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error(), "scored": scored})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Predictions scored successfully", "scored": scored})
+}
+
+// CompareClassifiers measures every stored model version against the gold labels
+func (vc *vibecheckController) CompareClassifiers(c *gin.Context) {
+	comparisons, err := vc.vibecheckService.CompareClassifiers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Classifiers compared successfully", "models": comparisons})
+}
+
+// GetTweetPredictions retrieves the stored predictions of every classifier for a tweet
+func (vc *vibecheckController) GetTweetPredictions(c *gin.Context) {
+	predictions, err := vc.vibecheckService.GetTweetPredictions(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrTweetNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Predictions retrieved successfully", "predictions": predictions})
+}
@@ -1,25 +1,36 @@
 package controllers
 
 import (
-	"database/sql"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
+	"vibecheck/config"
+	"vibecheck/graph"
 	"vibecheck/models"
 	"vibecheck/services"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 )
 
 type vibecheckController struct {
-	vibecheckService services.VibecheckService
+	vibecheckService *services.VibecheckService
+	graph            *graph.Schema
 	listPerPage      int
+	maxDistance      int
+	adminToken       string
 }
 
-// NewvibecheckController creates a new vibecheck controller
-func NewVibecheckController(db *sql.DB, redisClient *redis.Client, lpp int) *vibecheckController {
-	vibecheckService := services.NewVibecheckService(db, redisClient)
-	return &vibecheckController{vibecheckService: *vibecheckService, listPerPage: lpp}
+// NewvibecheckController creates a new vibecheck controller over the service the background
+// workers run on, so events queued by requests wake this replica's workers
+func NewVibecheckController(vibecheckService *services.VibecheckService, cfg config.Config) *vibecheckController {
+	vc := &vibecheckController{vibecheckService: vibecheckService, listPerPage: cfg.ListPerPage, maxDistance: cfg.Duplicates.MaxDistance, adminToken: cfg.AdminToken}
+	schema, err := graph.New(vibecheckService, cfg)
+	if err != nil {
+		log.Fatalf("Failed to build the GraphQL schema: %v\n", err)
+	}
+	vc.graph = schema
+	return vc
 }
 
 // GetTweets retrieves all tweets from the database
@@ -55,12 +66,23 @@ func (vc *vibecheckController) NewTweet(c *gin.Context) {
 		return
 	}
 
-	err := vc.vibecheckService.NewTweet(&tweet)
+	duplicates, err := vc.vibecheckService.NewTweet(&tweet)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidStatus) || errors.Is(err, services.ErrInvalidPublishAt) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if rejectedContent(c, err) || vc.duplicateContent(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"message": "Tweet created successfully", "tweet": tweet})
+	response := gin.H{"message": "Tweet created successfully", "tweet": tweet}
+	if len(duplicates) > 0 {
+		response["duplicates"] = duplicates
+	}
+	c.JSON(http.StatusCreated, response)
 }
 
 // GetTweet retrieves a tweet by its ID
@@ -84,6 +106,9 @@ func (vc *vibecheckController) UpdateTweet(c *gin.Context) {
 	}
 	err := vc.vibecheckService.UpdateTweet(&tweet)
 	if err != nil {
+		if vc.duplicateContent(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -128,7 +153,7 @@ func (vc *vibecheckController) GetProblemsByPage(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Problems retrieved successfully", "problems": problems})
 }
 
-// NewProblem creates a new problem in the database
+// NewProblem submits a new problem for moderation
 func (vc *vibecheckController) NewProblem(c *gin.Context) {
 	var problem models.NewProblem
 	if err := c.ShouldBindJSON(&problem); err != nil {
@@ -136,12 +161,20 @@ func (vc *vibecheckController) NewProblem(c *gin.Context) {
 		return
 	}
 
-	err := vc.vibecheckService.NewProblem(&problem)
+	duplicates, err := vc.vibecheckService.NewProblem(&problem)
 	if err != nil {
+		if rejectedContent(c, err) || vc.duplicateContent(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"message": "Problem created successfully", "problem": problem})
+	response := gin.H{"message": "Problem submitted for moderation", "problem": problem}
+	// Players are not shown other tweets, which may not be published
+	if len(duplicates) > 0 && vc.privileged(c) {
+		response["duplicates"] = duplicates
+	}
+	c.JSON(http.StatusCreated, response)
 }
 
 // GetProblem retrieves a tweet without hint and answer by its ID
@@ -152,32 +185,56 @@ func (vc *vibecheckController) GetProblem(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	problem.Difficulty, err = vc.vibecheckService.GetDifficulty(problem.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	vc.vibecheckService.MarkServed(playerID(c), problem.ID)
 	c.JSON(http.StatusOK, gin.H{"message": "Problem retrieved successfully", "problem": problem})
 }
 
-// GetProblem retrieves a tweet without hint and answer
+// GetRandomProblem retrieves a random tweet without hint and answer that the caller has not seen yet
 func (vc *vibecheckController) GetRandomProblem(c *gin.Context) {
-	problem, err := vc.vibecheckService.GetRandomProblem()
+	opts := services.QuizOptions{
+		ViewerID:   viewerID(c),
+		PlayerID:   playerID(c),
+		ModelWrong: c.Query("modelWrong") == "true",
+		Collection: c.Query("collection"),
+	}
+	problem, err := vc.vibecheckService.GetRandomProblem(opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	vc.vibecheckService.MarkServed(playerID(c), problem.ID)
 	c.JSON(http.StatusOK, gin.H{"message": "Problem retrieved successfully", "problem": problem})
 }
 
-// AnswerProblem checks if the user's solution is correct
+// AnswerProblem checks if the user's solution is correct and scores it
 func (vc *vibecheckController) AnswerProblem(c *gin.Context) {
 	var attempt models.AttemptSolution
 	if err := c.ShouldBindJSON(&attempt); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	correct, err := vc.vibecheckService.CheckSolution(&attempt)
+	if !models.IsValidLabel(attempt.Guess) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid guess"})
+		return
+	}
+	result, err := vc.vibecheckService.SubmitAnswer(playerID(c), &attempt)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, services.ErrVoteRequiresPlayer):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrAlreadyAnswered), errors.Is(err, services.ErrDailyProblem):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"correct": correct})
+	c.JSON(http.StatusOK, result)
 }
 
 // GetHint retrieves a hint for a tweet
@@ -188,5 +245,6 @@ func (vc *vibecheckController) GetHint(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	vc.vibecheckService.RecordHintUsed(playerID(c), id)
 	c.JSON(http.StatusOK, gin.H{"hint": hint})
 }
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDailyChallenge retrieves today's challenge problems
+func (vc *vibecheckController) GetDailyChallenge(c *gin.Context) {
+	challenge, err := vc.vibecheckService.GetDailyChallenge()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Daily challenge retrieved successfully", "challenge": challenge})
+}
+
+// AnswerDailyChallenge records the caller's single attempt at a daily challenge problem
+func (vc *vibecheckController) AnswerDailyChallenge(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	var attempt models.AttemptSolution
+	if err := c.ShouldBindJSON(&attempt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !models.IsValidLabel(attempt.Guess) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid guess"})
+		return
+	}
+	result, err := vc.vibecheckService.SubmitDailyAnswer(id, &attempt)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAlreadyAnswered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrNotInChallenge):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetDailyResults retrieves how the community answered a daily challenge
+func (vc *vibecheckController) GetDailyResults(c *gin.Context) {
+	results, err := vc.vibecheckService.GetDailyResults(c.Query("day"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidDay):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrDailyNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Daily results retrieved successfully", "results": results})
+}
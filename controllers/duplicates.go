@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// duplicateContent responds to a submission duplicating existing tweets, if it does. Only
+// privileged callers are shown the tweets, which may not be published.
+func (vc *vibecheckController) duplicateContent(c *gin.Context, err error) bool {
+	if errors.Is(err, services.ErrDuplicateTweet) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return true
+	}
+	var duplicate *services.DuplicateError
+	if !errors.As(err, &duplicate) {
+		return false
+	}
+	response := gin.H{"error": err.Error()}
+	if vc.privileged(c) {
+		response["duplicates"] = duplicate.Matches
+	}
+	c.JSON(http.StatusConflict, response)
+	return true
+}
+
+// GetDuplicateClusters lists clusters of exact and near duplicate tweets
+func (vc *vibecheckController) GetDuplicateClusters(c *gin.Context) {
+	distance, err := strconv.Atoi(c.DefaultQuery("distance", strconv.Itoa(vc.maxDistance)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid distance"})
+		return
+	}
+
+	clusters, err := vc.vibecheckService.GetDuplicateClusters(distance)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDistance) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Duplicate clusters retrieved successfully", "clusters": clusters})
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventHeartbeat is how often an idle event stream sends a comment, so proxies keep it open
+const eventHeartbeat = 30 * time.Second
+
+// StreamEvents streams live game events as Server-Sent Events. Clients resume with the
+// Last-Event-ID header, which EventSource sends when it reconnects, or the lastEventId
+// query parameter.
+func (vc *vibecheckController) StreamEvents(c *gin.Context) {
+	lastEventID := strings.TrimSpace(c.GetHeader("Last-Event-ID"))
+	if lastEventID == "" {
+		lastEventID = strings.TrimSpace(c.Query("lastEventId"))
+	}
+
+	events, err := vc.vibecheckService.SubscribeEvents(c.Request.Context(), lastEventID)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEventID) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"vibecheck/graph"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQL executes a GraphQL request, sent as JSON or, for GET requests, as the query,
+// operationName and variables parameters. Errors are reported in the response body as
+// GraphQL specifies; requests that could not run at all get a 400 status.
+func (vc *vibecheckController) GraphQL(c *gin.Context) {
+	var request graph.Request
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid variables, expected a JSON object"})
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(request.Query) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing query"})
+		return
+	}
+
+	caller := graph.Caller{PlayerID: playerID(c), Privileged: vc.privileged(c)}
+	result := vc.graph.Execute(c.Request.Context(), caller, request)
+	if result.Data == nil && result.HasErrors() {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// hintError responds with the status matching a hint service error
+func hintError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTweetNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrHintRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrHintNotGenerated):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		if !rejectedContent(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+	}
+}
+
+// GetGeneratedHints retrieves the generated hints waiting for a curator
+func (vc *vibecheckController) GetGeneratedHints(c *gin.Context) {
+	hints, err := vc.vibecheckService.GetGeneratedHints()
+	if err != nil {
+		hintError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Generated hints retrieved successfully", "hints": hints})
+}
+
+// GenerateMissingHints generates hints for every tweet without one
+func (vc *vibecheckController) GenerateMissingHints(c *gin.Context) {
+	generated, err := vc.vibecheckService.GenerateMissingHints()
+	if err != nil {
+		hintError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Hints generated successfully", "generated": generated})
+}
+
+// AcceptHint keeps a tweet's generated hint
+func (vc *vibecheckController) AcceptHint(c *gin.Context) {
+	tweet, err := vc.vibecheckService.AcceptHint(c.Param("id"))
+	if err != nil {
+		hintError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Hint accepted successfully", "tweet": tweet})
+}
+
+// OverwriteHint replaces a tweet's hint with a curated one
+func (vc *vibecheckController) OverwriteHint(c *gin.Context) {
+	var update models.HintUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tweet, err := vc.vibecheckService.OverwriteHint(c.Param("id"), strings.TrimSpace(update.Hint))
+	if err != nil {
+		hintError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Hint updated successfully", "tweet": tweet})
+}
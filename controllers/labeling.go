@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLabelingProblem retrieves a random tweet without a gold answer for the caller to label
+func (vc *vibecheckController) GetLabelingProblem(c *gin.Context) {
+	problem, err := vc.vibecheckService.GetLabelingProblem(services.QuizOptions{ViewerID: viewerID(c)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	vc.vibecheckService.MarkServed(playerID(c), problem.ID)
+	c.JSON(http.StatusOK, gin.H{"message": "Problem retrieved successfully", "problem": problem})
+}
+
+// GetLabelingItem retrieves the votes, consensus label and agreement of a labeling item.
+// Players only see them once they have voted, so they cannot follow the crowd.
+func (vc *vibecheckController) GetLabelingItem(c *gin.Context) {
+	item, err := vc.vibecheckService.GetLabelingItem(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrTweetNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !vc.privileged(c) {
+		voted, err := vc.vibecheckService.HasVoted(playerID(c), item.TweetID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !voted {
+			hidden := gin.H{"tweetId": item.TweetID, "text": item.Text, "totalVotes": item.TotalVotes}
+			c.JSON(http.StatusOK, gin.H{"message": "Labeling item retrieved successfully", "item": hidden})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Labeling item retrieved successfully", "item": item})
+}
+
+// GetLabelingReport retrieves consensus coverage and agreement over the labeling dataset
+func (vc *vibecheckController) GetLabelingReport(c *gin.Context) {
+	report, err := vc.vibecheckService.GetLabelingReport()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Labeling report retrieved successfully", "report": report})
+}
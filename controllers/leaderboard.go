@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxLeaderboardSize = 100
+
+// GetLeaderboard retrieves the daily, weekly or all-time leaderboard, globally or for a collection
+func (vc *vibecheckController) GetLeaderboard(c *gin.Context) {
+	period := c.DefaultQuery("period", services.PeriodAllTime)
+	collection := c.Query("collection")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(vc.listPerPage)))
+	if err != nil || limit < 1 || limit > maxLeaderboardSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	leaderboard, err := vc.vibecheckService.GetLeaderboard(period, collection, playerID(c), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPeriod) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period, expected daily, weekly or alltime"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Leaderboard retrieved successfully", "leaderboard": leaderboard})
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangeTweetStatus moves a tweet to another lifecycle status
+func (vc *vibecheckController) ChangeTweetStatus(c *gin.Context) {
+	var change models.StatusChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tweet, err := vc.vibecheckService.ChangeTweetStatus(c.Param("id"), &change)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTweetNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidStatus), errors.Is(err, services.ErrInvalidPublishAt):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Tweet status updated successfully", "tweet": tweet})
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"vibecheck/models"
+	"vibecheck/safety"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// moderationError responds with the status matching a moderation service error
+func moderationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTweetNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrReasonRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// rejectedContent responds with the reasons content failed the safety checks, if it did
+func rejectedContent(c *gin.Context, err error) bool {
+	var rejection *safety.Rejection
+	if !errors.As(err, &rejection) {
+		return false
+	}
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "reasons": rejection.Reasons})
+	return true
+}
+
+// GetModerationQueue retrieves the submissions waiting for moderation
+func (vc *vibecheckController) GetModerationQueue(c *gin.Context) {
+	submissions, err := vc.vibecheckService.GetModerationQueue()
+	if err != nil {
+		moderationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Moderation queue retrieved successfully", "submissions": submissions})
+}
+
+// ApproveSubmission publishes a pending submission
+func (vc *vibecheckController) ApproveSubmission(c *gin.Context) {
+	submission, err := vc.vibecheckService.ApproveSubmission(c.Param("id"), adminModerator)
+	if err != nil {
+		moderationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Submission approved successfully", "submission": submission})
+}
+
+// RejectSubmission rejects a pending submission with a reason
+func (vc *vibecheckController) RejectSubmission(c *gin.Context) {
+	var decision models.ModerationDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	submission, err := vc.vibecheckService.RejectSubmission(c.Param("id"), adminModerator, strings.TrimSpace(decision.Reason))
+	if err != nil {
+		moderationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Submission rejected successfully", "submission": submission})
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxPlayerIDLength = 64
+
+// playerID returns the caller's player identifier from the X-Player-ID header,
+// or an empty string for anonymous callers
+func playerID(c *gin.Context) string {
+	id := strings.TrimSpace(c.GetHeader("X-Player-ID"))
+	if len(id) > maxPlayerIDLength {
+		return ""
+	}
+	return id
+}
+
+// viewerID identifies the caller for no-repeat serving: their player ID when known,
+// otherwise the session from the X-Session-ID header
+func viewerID(c *gin.Context) string {
+	if id := playerID(c); id != "" {
+		return services.PlayerViewerID(id)
+	}
+	id := strings.TrimSpace(c.GetHeader("X-Session-ID"))
+	if id == "" || len(id) > maxPlayerIDLength {
+		return ""
+	}
+	return services.SessionViewerID(id)
+}
+
+// requirePlayerID returns the caller's player identifier, responding with an error if it is missing
+func requirePlayerID(c *gin.Context) (string, bool) {
+	id := playerID(c)
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or invalid X-Player-ID header"})
+		return "", false
+	}
+	return id, true
+}
+
+// GetMyStats retrieves score, streaks, accuracy per label and recent attempts for the caller
+func (vc *vibecheckController) GetMyStats(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	stats, err := vc.vibecheckService.GetPlayerStats(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Stats retrieved successfully", "stats": stats})
+}
+
+// GetMyVersus compares the caller's accuracy with the built-in classifier's on the same problems
+func (vc *vibecheckController) GetMyVersus(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	versus, err := vc.vibecheckService.GetVersus(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Versus stats retrieved successfully", "versus": versus})
+}
+
+// GetVersus compares all players' accuracy with the built-in classifier's, per collection
+func (vc *vibecheckController) GetVersus(c *gin.Context) {
+	versus, err := vc.vibecheckService.GetVersus("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Versus stats retrieved successfully", "versus": versus})
+}
@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPrediction retrieves what the built-in sentiment classifier thinks of a tweet
+func (vc *vibecheckController) GetPrediction(c *gin.Context) {
+	prediction, err := vc.vibecheckService.GetPrediction(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrTweetNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Prediction retrieved successfully", "prediction": prediction})
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDatasetReport summarizes the health of the dataset, as JSON or Markdown
+func (vc *vibecheckController) GetDatasetReport(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "markdown" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected json or markdown"})
+		return
+	}
+
+	report, err := vc.vibecheckService.GetDatasetReport(c.Query("status"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "markdown" {
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(services.DatasetReportMarkdown(report)))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Dataset report generated successfully", "report": report})
+}
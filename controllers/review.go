@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reviewError responds with the status matching a review service error
+func reviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidReviewItem):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrReviewDecided):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetReviewQueue retrieves the problems flagged for label review
+func (vc *vibecheckController) GetReviewQueue(c *gin.Context) {
+	items, err := vc.vibecheckService.GetReviewQueue(c.DefaultQuery("status", services.ReviewPending))
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Review queue retrieved successfully", "items": items})
+}
+
+// ScanDisagreements flags problems whose answers contradict their gold label
+func (vc *vibecheckController) ScanDisagreements(c *gin.Context) {
+	flagged, err := vc.vibecheckService.ScanDisagreements()
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Disagreement scan completed successfully", "flagged": flagged})
+}
+
+// ConfirmReviewItem keeps the gold label of a flagged problem
+func (vc *vibecheckController) ConfirmReviewItem(c *gin.Context) {
+	var decision models.ReviewDecision
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&decision); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	item, err := vc.vibecheckService.ConfirmReviewItem(c.Param("id"), adminModerator, &decision)
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Label confirmed successfully", "item": item})
+}
+
+// RelabelReviewItem changes the gold label of a flagged problem
+func (vc *vibecheckController) RelabelReviewItem(c *gin.Context) {
+	var decision models.ReviewDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	item, err := vc.vibecheckService.RelabelReviewItem(c.Param("id"), adminModerator, &decision)
+	if err != nil {
+		reviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Problem relabeled successfully", "item": item})
+}
@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// socketWriteWait bounds how long a write to a room socket may block
+	socketWriteWait = 10 * time.Second
+	// socketPongWait is how long a room socket may stay silent before it is dropped;
+	// pings are sent often enough to keep live sockets within it
+	socketPongWait     = 60 * time.Second
+	socketPingInterval = socketPongWait * 9 / 10
+	socketMaxMessage   = 1024
+)
+
+// Origins are not checked, like the CORS configuration allows every origin
+var roomUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// socketMessage is an action a player sends over a room socket
+type socketMessage struct {
+	Type  string `json:"type"`
+	Guess string `json:"guess"`
+}
+
+// roomError responds with the status matching a room service error
+func roomError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidRoom):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNotRoomHost), errors.Is(err, services.ErrNotInRoom):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRoomFinished), errors.Is(err, services.ErrNoOpenQuestion), errors.Is(err, services.ErrAlreadyAnswered):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateRoom opens a live room hosted by the caller
+func (vc *vibecheckController) CreateRoom(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	var newRoom models.NewRoom
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&newRoom); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	room, err := vc.vibecheckService.CreateRoom(id, &newRoom)
+	if err != nil {
+		roomError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Room created successfully", "room": room})
+}
+
+// GetRoom retrieves a room, its players and the problem currently shown
+func (vc *vibecheckController) GetRoom(c *gin.Context) {
+	room, err := vc.vibecheckService.GetRoom(c.Param("code"))
+	if err != nil {
+		roomError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Room retrieved successfully", "room": room})
+}
+
+// JoinRoom adds the caller to a room
+func (vc *vibecheckController) JoinRoom(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	room, err := vc.vibecheckService.JoinRoom(id, c.Param("code"))
+	if err != nil {
+		roomError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Room joined successfully", "room": room})
+}
+
+// NextRoomProblem reveals the open problem of the caller's room and opens the next one
+func (vc *vibecheckController) NextRoomProblem(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	room, err := vc.vibecheckService.NextRoomProblem(id, c.Param("code"))
+	if err != nil {
+		roomError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Room advanced successfully", "room": room})
+}
+
+// AnswerRoom records the caller's answer to the open problem of a room
+func (vc *vibecheckController) AnswerRoom(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	var guess models.RoomGuess
+	if err := c.ShouldBindJSON(&guess); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !models.IsValidLabel(guess.Guess) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid guess"})
+		return
+	}
+	if err := vc.vibecheckService.AnswerRoom(id, c.Param("code"), guess.Guess); err != nil {
+		roomError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Answer recorded successfully"})
+}
+
+// RoomSocket streams a room's events over a WebSocket. Browsers cannot set headers on
+// WebSockets, so the player may also be given as the playerId query parameter; players
+// can answer and hosts can advance the room over the socket.
+func (vc *vibecheckController) RoomSocket(c *gin.Context) {
+	room, err := vc.vibecheckService.GetRoom(c.Param("code"))
+	if err != nil {
+		roomError(c, err)
+		return
+	}
+	player := playerID(c)
+	if player == "" {
+		if id := strings.TrimSpace(c.Query("playerId")); len(id) <= maxPlayerIDLength {
+			player = id
+		}
+	}
+
+	conn, err := roomUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already responded
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	events, err := vc.vibecheckService.SubscribeRoom(ctx, room.Code)
+	if err != nil {
+		conn.WriteJSON(models.RoomEvent{Type: services.RoomEventError, Code: room.Code, Error: err.Error(), At: time.Now().UTC()})
+		return
+	}
+	replies := make(chan models.RoomEvent)
+	go vc.readRoomSocket(ctx, cancel, conn, room.Code, player, replies)
+
+	conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+	if err := conn.WriteJSON(models.RoomEvent{Type: services.RoomEventState, Code: room.Code, Position: room.Position, Room: room, At: time.Now().UTC()}); err != nil {
+		return
+	}
+
+	ping := time.NewTicker(socketPingInterval)
+	defer ping.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			err = conn.WriteMessage(websocket.TextMessage, []byte(event))
+		case reply := <-replies:
+			conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			err = conn.WriteJSON(reply)
+		case <-ping.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteWait))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readRoomSocket handles the actions a player sends over a room socket, replying with an
+// error event when one fails. It cancels the socket once the connection is closed.
+func (vc *vibecheckController) readRoomSocket(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, code, player string, replies chan<- models.RoomEvent) {
+	defer cancel()
+	conn.SetReadLimit(socketMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var message socketMessage
+		problem := ""
+		switch {
+		case json.Unmarshal(data, &message) != nil:
+			problem = "Invalid message, expected JSON"
+		case player == "":
+			problem = "Missing or invalid X-Player-ID header or playerId parameter"
+		case message.Type == "answer" && !models.IsValidLabel(message.Guess):
+			problem = "Invalid guess"
+		case message.Type == "answer":
+			err = vc.vibecheckService.AnswerRoom(player, code, message.Guess)
+		case message.Type == "next":
+			_, err = vc.vibecheckService.NextRoomProblem(player, code)
+		default:
+			problem = "Unknown message type, expected answer or next"
+		}
+		if err != nil {
+			problem = err.Error()
+		}
+		if problem == "" {
+			continue
+		}
+		select {
+		case replies <- models.RoomEvent{Type: services.RoomEventError, Code: code, PlayerID: player, Error: problem, At: time.Now().UTC()}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// roundError responds with the status matching a round service error
+func roundError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrRoundNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidRound), errors.Is(err, services.ErrNotInRound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRoundClosed), errors.Is(err, services.ErrRoundExpired), errors.Is(err, services.ErrAlreadyAnswered):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// StartRound starts a timed round of problems for the caller
+func (vc *vibecheckController) StartRound(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	var newRound models.NewRound
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&newRound); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	round, err := vc.vibecheckService.StartRound(id, &newRound)
+	if err != nil {
+		roundError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Round started successfully", "round": round})
+}
+
+// GetRound retrieves one of the caller's rounds
+func (vc *vibecheckController) GetRound(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	round, err := vc.vibecheckService.GetRound(id, c.Param("id"))
+	if err != nil {
+		roundError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Round retrieved successfully", "round": round})
+}
+
+// GetMyRounds retrieves the caller's most recent rounds
+func (vc *vibecheckController) GetMyRounds(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	rounds, err := vc.vibecheckService.ListRounds(id)
+	if err != nil {
+		roundError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Rounds retrieved successfully", "rounds": rounds})
+}
+
+// AnswerRound submits the caller's answer to a problem of an open round
+func (vc *vibecheckController) AnswerRound(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	var attempt models.AttemptSolution
+	if err := c.ShouldBindJSON(&attempt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !models.IsValidLabel(attempt.Guess) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid guess"})
+		return
+	}
+	result, err := vc.vibecheckService.AnswerRound(id, c.Param("id"), &attempt)
+	if err != nil {
+		roundError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CloseRound closes one of the caller's rounds and returns its summary
+func (vc *vibecheckController) CloseRound(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	round, err := vc.vibecheckService.CloseRound(id, c.Param("id"))
+	if err != nil {
+		roundError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Round closed successfully", "round": round})
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondProblemStats responds with problem stats or the status matching the service error
+func respondProblemStats(c *gin.Context, stats *models.ProblemStats, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTweetNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrNotAnswered):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Stats retrieved successfully", "stats": stats})
+}
+
+// GetTweetStats retrieves the answer statistics of a tweet
+func (vc *vibecheckController) GetTweetStats(c *gin.Context) {
+	stats, err := vc.vibecheckService.GetProblemStats(c.Param("id"))
+	respondProblemStats(c, stats, err)
+}
+
+// GetProblemStats retrieves the answer statistics of a problem the caller has answered
+func (vc *vibecheckController) GetProblemStats(c *gin.Context) {
+	id, ok := requirePlayerID(c)
+	if !ok {
+		return
+	}
+	stats, err := vc.vibecheckService.GetAnsweredProblemStats(id, c.Param("id"))
+	respondProblemStats(c, stats, err)
+}
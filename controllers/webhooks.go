@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// webhookError responds with the status matching a webhook service error
+func webhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrWebhookNotFound), errors.Is(err, services.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidWebhook), errors.Is(err, services.ErrPrivateWebhook), errors.Is(err, services.ErrInvalidDeliveryStatus):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// RegisterWebhook registers an endpoint to notify of events, returning its signing secret once
+func (vc *vibecheckController) RegisterWebhook(c *gin.Context) {
+	var newWebhook models.NewWebhook
+	if err := c.ShouldBindJSON(&newWebhook); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	webhook, err := vc.vibecheckService.RegisterWebhook(&newWebhook)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Webhook registered successfully", "webhook": webhook})
+}
+
+// GetWebhooks lists the registered webhooks
+func (vc *vibecheckController) GetWebhooks(c *gin.Context) {
+	webhooks, err := vc.vibecheckService.ListWebhooks()
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhooks retrieved successfully", "webhooks": webhooks})
+}
+
+// DeleteWebhook removes a webhook and its delivery log
+func (vc *vibecheckController) DeleteWebhook(c *gin.Context) {
+	if err := vc.vibecheckService.DeleteWebhook(c.Param("id")); err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// GetWebhookDeliveries retrieves a webhook's most recent deliveries
+func (vc *vibecheckController) GetWebhookDeliveries(c *gin.Context) {
+	deliveries, err := vc.vibecheckService.GetWebhookDeliveries(c.Param("id"), c.Query("status"))
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Deliveries retrieved successfully", "deliveries": deliveries})
+}
+
+// RedeliverWebhook queues a new delivery of the event of an earlier delivery
+func (vc *vibecheckController) RedeliverWebhook(c *gin.Context) {
+	delivery, err := vc.vibecheckService.RedeliverWebhook(c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Delivery queued successfully", "delivery": delivery})
+}
@@ -0,0 +1,73 @@
+// Package dataloader batches lookups made while resolving a GraphQL query.
+//
+// Resolvers call Load for the keys they need and get back a thunk instead of a value.
+// The executor resolves every field at one level of the query before calling any of the
+// thunks, so by the time the first thunk runs, all the keys of that level are known and
+// are fetched together in a single call. Results are cached for the life of the loader,
+// which is meant to live for a single request.
+package dataloader
+
+import "sync"
+
+// BatchFunc fetches the values of several keys at once. Keys missing from the result have
+// no value.
+type BatchFunc[K comparable, V any] func(keys []K) (map[K]V, error)
+
+// Loader collects keys and fetches them in batches
+type Loader[K comparable, V any] struct {
+	fetch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+// New creates a loader fetching with fetch
+func New[K comparable, V any](fetch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:  fetch,
+		queued: map[K]bool{},
+		values: map[K]V{},
+		errs:   map[K]error{},
+	}
+}
+
+// Load queues key for the next batch and returns a thunk yielding its value. The second
+// result of the thunk reports whether the key has a value.
+func (l *Loader[K, V]) Load(key K) func() (V, bool, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, done := l.errs[key]; !done {
+			l.dispatch()
+		}
+		value, ok := l.values[key]
+		return value, ok, l.errs[key]
+	}
+}
+
+// dispatch fetches every pending key; l.mu must be held
+func (l *Loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		l.errs[key] = err
+		if value, ok := values[key]; ok && err == nil {
+			l.values[key] = value
+		}
+	}
+}
@@ -2,9 +2,178 @@ CREATE TABLE IF NOT EXISTS tweets (
     id UUID PRIMARY KEY,
     text TEXT NOT NULL,
     hint TEXT,
-    answer VARCHAR(10) CHECK (answer IN ('positive', 'negative', 'neutral'))
+    hint_generated BOOLEAN NOT NULL DEFAULT FALSE,
+    answer VARCHAR(10) CHECK (answer IN ('positive', 'negative', 'neutral')),
+    collection VARCHAR(64),
+    status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'scheduled', 'pending', 'published', 'rejected', 'archived')),
+    publish_at TIMESTAMPTZ,
+    safety_flags JSONB,
+    text_hash CHAR(40),
+    known_duplicate BOOLEAN NOT NULL DEFAULT FALSE,
+    simhash BIGINT,
+    simhash_bands BIGINT[],
+    moderation_reason TEXT,
+    moderated_by VARCHAR(64),
+    moderated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
+CREATE INDEX IF NOT EXISTS tweets_status_idx ON tweets (status);
+CREATE INDEX IF NOT EXISTS tweets_text_hash_idx ON tweets (text_hash);
+-- Exact duplicates already in the seeded dataset are marked when fingerprinted and exempted
+CREATE UNIQUE INDEX IF NOT EXISTS tweets_text_hash_unique_idx ON tweets (text_hash) WHERE NOT known_duplicate;
+CREATE INDEX IF NOT EXISTS tweets_simhash_bands_idx ON tweets USING GIN (simhash_bands);
+CREATE INDEX IF NOT EXISTS tweets_scheduled_idx ON tweets (publish_at) WHERE status = 'scheduled';
+
+CREATE TABLE IF NOT EXISTS players (
+    id VARCHAR(64) PRIMARY KEY,
+    score INTEGER NOT NULL DEFAULT 0,
+    current_streak INTEGER NOT NULL DEFAULT 0,
+    best_streak INTEGER NOT NULL DEFAULT 0,
+    rating DOUBLE PRECISION NOT NULL DEFAULT 1200,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS attempts (
+    id UUID PRIMARY KEY,
+    player_id VARCHAR(64),
+    tweet_id UUID NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    guess VARCHAR(10) NOT NULL,
+    correct BOOLEAN NOT NULL,
+    hints_used INTEGER NOT NULL DEFAULT 0,
+    response_ms BIGINT,
+    points INTEGER NOT NULL DEFAULT 0,
+    scored BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS attempts_player_idx ON attempts (player_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS attempts_tweet_idx ON attempts (tweet_id);
+CREATE UNIQUE INDEX IF NOT EXISTS attempts_scored_idx ON attempts (player_id, tweet_id) WHERE scored;
+
+CREATE TABLE IF NOT EXISTS label_votes (
+    tweet_id UUID NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    player_id VARCHAR(64) NOT NULL,
+    label VARCHAR(10) NOT NULL CHECK (label IN ('positive', 'negative', 'neutral')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tweet_id, player_id)
+);
+
+CREATE TABLE IF NOT EXISTS review_queue (
+    tweet_id UUID PRIMARY KEY REFERENCES tweets(id) ON DELETE CASCADE,
+    gold_label VARCHAR(10) NOT NULL,
+    suggested_label VARCHAR(10) NOT NULL,
+    disagreement DOUBLE PRECISION NOT NULL,
+    attempts INTEGER NOT NULL,
+    guesses JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'relabeled')),
+    decision_label VARCHAR(10),
+    decided_by VARCHAR(64),
+    decision_note TEXT,
+    flagged_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS problem_ratings (
+    tweet_id UUID PRIMARY KEY REFERENCES tweets(id) ON DELETE CASCADE,
+    rating DOUBLE PRECISION NOT NULL DEFAULT 1200,
+    attempts INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS predictions (
+    tweet_id UUID NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    model VARCHAR(64) NOT NULL,
+    version VARCHAR(32) NOT NULL,
+    label VARCHAR(10) NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tweet_id, model, version)
+);
+
+CREATE TABLE IF NOT EXISTS daily_challenges (
+    day DATE PRIMARY KEY,
+    tweet_ids UUID[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS daily_challenge_attempts (
+    day DATE NOT NULL,
+    player_id VARCHAR(64) NOT NULL,
+    tweet_id UUID NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    guess VARCHAR(10) NOT NULL,
+    correct BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, player_id, tweet_id)
+);
+
+CREATE TABLE IF NOT EXISTS rounds (
+    id UUID PRIMARY KEY,
+    player_id VARCHAR(64) NOT NULL,
+    size INTEGER NOT NULL,
+    time_limit_seconds INTEGER NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    score INTEGER NOT NULL DEFAULT 0,
+    summary JSONB
+);
+
+CREATE INDEX IF NOT EXISTS rounds_player_idx ON rounds (player_id, started_at DESC);
+
+CREATE TABLE IF NOT EXISTS round_items (
+    round_id UUID NOT NULL REFERENCES rounds(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    tweet_id UUID NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    guess VARCHAR(10),
+    correct BOOLEAN,
+    points INTEGER NOT NULL DEFAULT 0,
+    response_ms BIGINT,
+    answered_at TIMESTAMPTZ,
+    PRIMARY KEY (round_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    response_status INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON webhook_deliveries (webhook_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    aggregate_type VARCHAR(32) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp(),
+    published_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox (created_at) WHERE published_at IS NULL AND failed_at IS NULL;
+
 -- Check if the table is empty before loading data
 DO $$
 BEGIN
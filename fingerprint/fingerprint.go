@@ -0,0 +1,130 @@
+// Package fingerprint computes text fingerprints used to find duplicate tweets.
+//
+// Texts are normalized first so retweets, mentions, links and trailing hashtags do not
+// hide a copy. Exact duplicates share a hash of the normalized text; near duplicates
+// have SimHashes within a small Hamming distance of each other.
+package fingerprint
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	retweetPattern = regexp.MustCompile(`(?i)^\s*rt\s+@\w+:?`)
+	mentionPattern = regexp.MustCompile(`@\w+`)
+	linkPattern    = regexp.MustCompile(`(?i)\bhttps?://\S+|\bwww\.\S+`)
+)
+
+// Normalize reduces a tweet to its lowercase words, without the retweet prefix, mentions,
+// links, trailing hashtags or punctuation
+func Normalize(text string) string {
+	text = retweetPattern.ReplaceAllString(text, " ")
+	text = mentionPattern.ReplaceAllString(text, " ")
+	text = linkPattern.ReplaceAllString(text, " ")
+
+	words := strings.Fields(strings.ToLower(text))
+	for len(words) > 0 && strings.HasPrefix(words[len(words)-1], "#") {
+		words = words[:len(words)-1]
+	}
+
+	cleaned := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, word)
+		if word != "" {
+			cleaned = append(cleaned, word)
+		}
+	}
+	return strings.Join(cleaned, " ")
+}
+
+// Exact returns a hash of the normalized text, equal for exact duplicates
+func Exact(text string) string {
+	sum := sha1.Sum([]byte(Normalize(text)))
+	return hex.EncodeToString(sum[:])
+}
+
+// features returns the words and word pairs of a normalized text
+func features(normalized string) []string {
+	words := strings.Fields(normalized)
+	features := make([]string, 0, 2*len(words))
+	features = append(features, words...)
+	for i := 1; i < len(words); i++ {
+		features = append(features, words[i-1]+" "+words[i])
+	}
+	return features
+}
+
+// SimHash returns the 64-bit SimHash of the normalized text. Texts without any words hash to 0.
+func SimHash(text string) uint64 {
+	var weights [64]int
+	for _, feature := range features(Normalize(text)) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var hash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			hash |= 1 << bit
+		}
+	}
+	return hash
+}
+
+// Distance returns the number of bits two SimHashes differ in
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Band is a run of SimHash bits, keyed by its position so bands can be used as map keys
+type Band struct {
+	Index int
+	Bits  uint64
+}
+
+// Bands splits a SimHash into maxDistance+1 bands of bits. Two hashes within maxDistance
+// of each other agree on at least one band, so comparing hashes that share a band finds
+// every near duplicate.
+func Bands(hash uint64, maxDistance int) []Band {
+	n := maxDistance + 1
+	if n < 1 {
+		n = 1
+	}
+	if n > 64 {
+		n = 64
+	}
+	width := 64 / n
+	bands := make([]Band, n)
+	for i := 0; i < n; i++ {
+		start := i * width
+		end := start + width
+		if i == n-1 {
+			end = 64
+		}
+		mask := ^uint64(0)
+		if end-start < 64 {
+			mask = uint64(1)<<(end-start) - 1
+		}
+		bands[i] = Band{Index: i, Bits: (hash >> start) & mask}
+	}
+	return bands
+}
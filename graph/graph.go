@@ -0,0 +1,82 @@
+// Package graph serves the GraphQL API alongside the REST routes.
+//
+// The schema covers tweets, problems, the caller's attempts, collections and leaderboards,
+// all resolved through the service layer. Lookups made for every item of a list, like the
+// stats of each problem on a page, are batched per request through dataloaders. Queries
+// nested too deeply or estimated to cost too much are refused before they run.
+package graph
+
+import (
+	"context"
+	"vibecheck/config"
+	"vibecheck/services"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Caller describes who is making a request
+type Caller struct {
+	// PlayerID identifies the calling player, or is empty for anonymous callers
+	PlayerID string
+	// Privileged callers may read tweets with their answers and hints
+	Privileged bool
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Schema executes GraphQL requests against the service layer
+type Schema struct {
+	schema        graphql.Schema
+	service       *services.VibecheckService
+	listPerPage   int
+	maxDepth      int
+	maxComplexity int
+}
+
+// New builds the GraphQL schema over service
+func New(service *services.VibecheckService, cfg config.Config) (*Schema, error) {
+	s := &Schema{
+		service:       service,
+		listPerPage:   cfg.ListPerPage,
+		maxDepth:      cfg.GraphQL.MaxDepth,
+		maxComplexity: cfg.GraphQL.MaxComplexity,
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: s.queryType()})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute parses, validates and runs a request on behalf of caller
+func (s *Schema) Execute(ctx context.Context, caller Caller, req Request) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&s.schema, document, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if err := s.checkLimits(document, req.OperationName, req.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withRequest(ctx, caller, s.newLoaders(caller)),
+	})
+}
@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// checkLimits refuses an operation nested deeper than the maximum depth or estimated to
+// cost more than the maximum complexity. Every field costs one, and the fields below a
+// list count once for each item of a page, or of the limit the query asks for. Introspection
+// fields are not counted.
+func (s *Schema) checkLimits(document *ast.Document, operationName string, variables map[string]interface{}) error {
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		}
+	}
+	if operation == nil {
+		// The executor reports the missing operation
+		return nil
+	}
+
+	m := &measure{schema: s, fragments: fragments, variables: variables, measured: map[fragmentKey]cost{}}
+	for _, definition := range operation.VariableDefinitions {
+		name := definition.Variable.Name.Value
+		if _, ok := variables[name]; !ok && definition.DefaultValue != nil {
+			if m.defaults == nil {
+				m.defaults = map[string]ast.Value{}
+			}
+			m.defaults[name] = definition.DefaultValue
+		}
+	}
+	c := m.selectionSet(operation.SelectionSet, s.schema.QueryType(), s.listPerPage)
+	if s.maxDepth > 0 && c.depth > s.maxDepth {
+		return fmt.Errorf("query is nested %d levels deep, more than the limit of %d", c.depth, s.maxDepth)
+	}
+	if s.maxComplexity > 0 && c.complexity > s.maxComplexity {
+		return fmt.Errorf("query complexity is %d, more than the limit of %d", c.complexity, s.maxComplexity)
+	}
+	return nil
+}
+
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// fragmentKey identifies a fragment measured for lists of a page size
+type fragmentKey struct {
+	name    string
+	perPage int
+}
+
+// measure computes the cost of selection sets, measuring each fragment once per page size
+// however often it is spread
+type measure struct {
+	schema    *Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	defaults  map[string]ast.Value
+	measured  map[fragmentKey]cost
+}
+
+// selectionSet measures a selection set whose lists hold perPage items. A field's limit
+// argument sets how many items its own list holds, or the lists below it for other fields,
+// like the entries of a leaderboard.
+func (m *measure) selectionSet(set *ast.SelectionSet, parent graphql.Type, perPage int) cost {
+	var total cost
+	if set == nil {
+		return total
+	}
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			fieldType := m.fieldType(parent, selection.Name.Value)
+			named, _ := graphql.GetNamed(fieldType).(graphql.Type)
+			size := perPage
+			if limit, ok := m.limit(selection); ok {
+				size = limit
+			}
+			if isList(fieldType) {
+				c = m.selectionSet(selection.SelectionSet, named, m.schema.listPerPage)
+				c.complexity = saturatingMul(c.complexity, size)
+			} else {
+				c = m.selectionSet(selection.SelectionSet, named, size)
+			}
+			c.depth++
+			c.complexity = saturatingAdd(c.complexity, 1)
+		case *ast.InlineFragment:
+			fragmentType := parent
+			if selection.TypeCondition != nil {
+				fragmentType = m.schema.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			c = m.selectionSet(selection.SelectionSet, fragmentType, perPage)
+		case *ast.FragmentSpread:
+			c = m.fragment(selection.Name.Value, perPage)
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity = saturatingAdd(total.complexity, c.complexity)
+	}
+	return total
+}
+
+func (m *measure) fragment(name string, perPage int) cost {
+	key := fragmentKey{name: name, perPage: perPage}
+	if c, ok := m.measured[key]; ok {
+		return c
+	}
+	var c cost
+	if fragment, ok := m.fragments[name]; ok {
+		c = m.selectionSet(fragment.SelectionSet, m.schema.schema.Type(fragment.TypeCondition.Name.Value), perPage)
+	}
+	m.measured[key] = c
+	return c
+}
+
+// limit returns the positive limit argument of a field, given inline or as a variable
+func (m *measure) limit(field *ast.Field) (int, bool) {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		value := argument.Value
+		if variable, ok := value.(*ast.Variable); ok {
+			name := variable.Name.Value
+			if v, ok := m.variables[name]; ok {
+				return positiveInt(v)
+			}
+			if value, ok = m.defaults[name]; !ok {
+				return 0, false
+			}
+		}
+		if literal, ok := value.(*ast.IntValue); ok {
+			n, err := strconv.Atoi(literal.Value)
+			return n, err == nil && n > 0
+		}
+	}
+	return 0, false
+}
+
+// positiveInt reads a positive integer variable, which JSON decodes as a float
+func positiveInt(v interface{}) (int, bool) {
+	var n float64
+	switch v := v.(type) {
+	case int:
+		n = float64(v)
+	case float64:
+		n = v
+	default:
+		return 0, false
+	}
+	if n < 1 || n > math.MaxInt32 {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// fieldType looks up the type of a field of parent, or nil if parent has no such field
+func (m *measure) fieldType(parent graphql.Type, name string) graphql.Type {
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return nil
+	}
+	field, ok := object.Fields()[name]
+	if !ok {
+		return nil
+	}
+	return field.Type
+}
+
+func isList(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int) int {
+	if b > 0 && a > math.MaxInt32/b {
+		return math.MaxInt32
+	}
+	return a * b
+}
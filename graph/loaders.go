@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"context"
+	"vibecheck/dataloader"
+	"vibecheck/models"
+)
+
+// loaders batch the lookups of a single request
+type loaders struct {
+	tweets       *dataloader.Loader[string, *models.Tweet]
+	stats        *dataloader.Loader[string, *models.ProblemStats]
+	difficulties *dataloader.Loader[string, *models.Difficulty]
+	attempts     *dataloader.Loader[string, []models.Attempt]
+}
+
+func (s *Schema) newLoaders(caller Caller) *loaders {
+	return &loaders{
+		tweets:       dataloader.New(s.service.GetTweetsByIDs),
+		stats:        dataloader.New(s.service.GetProblemStatsBatch),
+		difficulties: dataloader.New(s.service.GetDifficulties),
+		attempts: dataloader.New(func(tweetIDs []string) (map[string][]models.Attempt, error) {
+			return s.service.GetPlayerAttempts(caller.PlayerID, tweetIDs)
+		}),
+	}
+}
+
+type requestKey struct{}
+
+// request is what resolvers know about the request being executed
+type request struct {
+	caller  Caller
+	loaders *loaders
+}
+
+func withRequest(ctx context.Context, caller Caller, loaders *loaders) context.Context {
+	return context.WithValue(ctx, requestKey{}, &request{caller: caller, loaders: loaders})
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// thunk defers a loader lookup until the executor asks for its value, so lookups queued by
+// sibling fields are fetched together. A key without a value resolves to null.
+func thunk[V any](load func() (V, bool, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, ok, err := load()
+		if err != nil || !ok {
+			return nil, err
+		}
+		return value, nil
+	}
+}
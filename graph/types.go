@@ -0,0 +1,440 @@
+package graph
+
+import (
+	"errors"
+	"vibecheck/models"
+	"vibecheck/services"
+
+	"github.com/graphql-go/graphql"
+)
+
+// maxLeaderboardSize caps how many leaderboard entries a query may ask for
+const maxLeaderboardSize = 100
+
+var (
+	ErrPrivileged   = errors.New("only privileged callers may read tweets")
+	ErrInvalidLimit = errors.New("invalid limit")
+)
+
+// guessCount is how many times a label was guessed for a problem
+type guessCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// labelAccuracy is a player's accuracy on problems with one gold label
+type labelAccuracy struct {
+	Label    string  `json:"label"`
+	Attempts int     `json:"attempts"`
+	Correct  int     `json:"correct"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+// sourceAs returns the source of a field as a T, whether it was given as a value or a pointer
+func sourceAs[T any](source interface{}) T {
+	if p, ok := source.(*T); ok {
+		return *p
+	}
+	return source.(T)
+}
+
+func nonNull(t graphql.Output) graphql.Output {
+	return graphql.NewNonNull(t)
+}
+
+func listOf(t graphql.Output) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+// queryType builds the types of the schema and the root query over them
+func (s *Schema) queryType() *graphql.Object {
+	difficultyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Difficulty",
+		Description: "Estimated difficulty of a problem, rated from the players who answered it",
+		Fields: graphql.Fields{
+			"rating":   &graphql.Field{Type: nonNull(graphql.Float)},
+			"attempts": &graphql.Field{Type: nonNull(graphql.Int)},
+			"level":    &graphql.Field{Type: nonNull(graphql.String)},
+		},
+	})
+
+	guessCountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GuessCount",
+		Fields: graphql.Fields{
+			"label": &graphql.Field{Type: nonNull(graphql.String)},
+			"count": &graphql.Field{Type: nonNull(graphql.Int)},
+		},
+	})
+
+	statsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ProblemStats",
+		Description: "Aggregate of every recorded attempt at a problem",
+		Fields: graphql.Fields{
+			"attempts": &graphql.Field{Type: nonNull(graphql.Int)},
+			"correct":  &graphql.Field{Type: nonNull(graphql.Int)},
+			"accuracy": &graphql.Field{Type: nonNull(graphql.Float)},
+			"guesses": &graphql.Field{
+				Type: listOf(guessCountType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					stats := sourceAs[models.ProblemStats](p.Source)
+					guesses := make([]guessCount, 0, len(models.Labels))
+					for _, label := range models.Labels {
+						guesses = append(guesses, guessCount{Label: label, Count: stats.Guesses[label]})
+					}
+					return guesses, nil
+				},
+			},
+			"medianResponseMs": &graphql.Field{Type: nonNull(graphql.Float)},
+			"hintUsageRate":    &graphql.Field{Type: nonNull(graphql.Float)},
+		},
+	})
+
+	attemptType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Attempt",
+		Description: "An answer the caller gave to a problem",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: nonNull(graphql.ID)},
+			"tweetId":    &graphql.Field{Type: nonNull(graphql.ID)},
+			"guess":      &graphql.Field{Type: nonNull(graphql.String)},
+			"answer":     &graphql.Field{Type: nonNull(graphql.String)},
+			"correct":    &graphql.Field{Type: nonNull(graphql.Boolean)},
+			"hintsUsed":  &graphql.Field{Type: nonNull(graphql.Int)},
+			"responseMs": &graphql.Field{Type: nonNull(graphql.Int)},
+			"points":     &graphql.Field{Type: nonNull(graphql.Int)},
+			"createdAt":  &graphql.Field{Type: nonNull(graphql.DateTime)},
+		},
+	})
+
+	// problemField resolves a field of a problem from its tweet, for privileged callers only
+	problemField := func(field func(tweet *models.Tweet) string) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			req := requestFrom(p.Context)
+			if !req.caller.Privileged {
+				return nil, nil
+			}
+			load := req.loaders.tweets.Load(sourceAs[models.Problem](p.Source).ID)
+			return func() (interface{}, error) {
+				tweet, ok, err := load()
+				if err != nil || !ok || field(tweet) == "" {
+					return nil, err
+				}
+				return field(tweet), nil
+			}, nil
+		}
+	}
+
+	problemType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Problem",
+		Description: "A published tweet to guess the sentiment of. Answers and hints are only shown to privileged callers.",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: nonNull(graphql.ID)},
+			"text": &graphql.Field{Type: nonNull(graphql.String)},
+			"collection": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					load := requestFrom(p.Context).loaders.tweets.Load(sourceAs[models.Problem](p.Source).ID)
+					return func() (interface{}, error) {
+						tweet, ok, err := load()
+						if err != nil || !ok || tweet.Collection == "" {
+							return nil, err
+						}
+						return tweet.Collection, nil
+					}, nil
+				},
+			},
+			"difficulty": &graphql.Field{
+				Type: nonNull(difficultyType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return thunk(requestFrom(p.Context).loaders.difficulties.Load(sourceAs[models.Problem](p.Source).ID)), nil
+				},
+			},
+			"stats": &graphql.Field{
+				Type:        statsType,
+				Description: "Only shown once the caller has answered the problem",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					req := requestFrom(p.Context)
+					id := sourceAs[models.Problem](p.Source).ID
+					stats := req.loaders.stats.Load(id)
+					if req.caller.Privileged {
+						return thunk(stats), nil
+					}
+					answered := req.loaders.attempts.Load(id)
+					return func() (interface{}, error) {
+						attempts, _, err := answered()
+						if err != nil {
+							return nil, err
+						}
+						if len(attempts) == 0 {
+							return nil, services.ErrNotAnswered
+						}
+						return thunk(stats)()
+					}, nil
+				},
+			},
+			"attempts": &graphql.Field{
+				Type:        listOf(attemptType),
+				Description: "The caller's attempts at the problem, newest first",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					load := requestFrom(p.Context).loaders.attempts.Load(sourceAs[models.Problem](p.Source).ID)
+					return func() (interface{}, error) {
+						attempts, _, err := load()
+						if err != nil {
+							return nil, err
+						}
+						if attempts == nil {
+							attempts = []models.Attempt{}
+						}
+						return attempts, nil
+					}, nil
+				},
+			},
+			"hintsUsed": &graphql.Field{
+				Type:        nonNull(graphql.Int),
+				Description: "How many hints the caller used over their attempts at the problem",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					load := requestFrom(p.Context).loaders.attempts.Load(sourceAs[models.Problem](p.Source).ID)
+					return func() (interface{}, error) {
+						attempts, _, err := load()
+						if err != nil {
+							return nil, err
+						}
+						hints := 0
+						for _, attempt := range attempts {
+							hints += attempt.HintsUsed
+						}
+						return hints, nil
+					}, nil
+				},
+			},
+			"answer": &graphql.Field{
+				Type:    graphql.String,
+				Resolve: problemField(func(tweet *models.Tweet) string { return tweet.Answer }),
+			},
+			"hint": &graphql.Field{
+				Type:    graphql.String,
+				Resolve: problemField(func(tweet *models.Tweet) string { return tweet.Hint }),
+			},
+		},
+	})
+
+	attemptType.AddFieldConfig("problem", &graphql.Field{
+		Type: problemType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			load := requestFrom(p.Context).loaders.tweets.Load(sourceAs[models.Attempt](p.Source).TweetID)
+			return func() (interface{}, error) {
+				tweet, ok, err := load()
+				if err != nil || !ok {
+					return nil, err
+				}
+				return models.Problem{ID: tweet.ID, Text: tweet.Text}, nil
+			}, nil
+		},
+	})
+
+	tweetType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Tweet",
+		Description: "A tweet with its answer and hint, only readable by privileged callers",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: nonNull(graphql.ID)},
+			"text":          &graphql.Field{Type: nonNull(graphql.String)},
+			"hint":          &graphql.Field{Type: nonNull(graphql.String)},
+			"hintGenerated": &graphql.Field{Type: nonNull(graphql.Boolean)},
+			"answer":        &graphql.Field{Type: nonNull(graphql.String)},
+			"collection":    &graphql.Field{Type: nonNull(graphql.String)},
+			"status":        &graphql.Field{Type: nonNull(graphql.String)},
+			"publishAt":     &graphql.Field{Type: graphql.DateTime},
+			"stats": &graphql.Field{
+				Type: statsType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return thunk(requestFrom(p.Context).loaders.stats.Load(sourceAs[models.Tweet](p.Source).ID)), nil
+				},
+			},
+			"difficulty": &graphql.Field{
+				Type: nonNull(difficultyType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return thunk(requestFrom(p.Context).loaders.difficulties.Load(sourceAs[models.Tweet](p.Source).ID)), nil
+				},
+			},
+		},
+	})
+
+	collectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Collection",
+		Fields: graphql.Fields{
+			"name":     &graphql.Field{Type: nonNull(graphql.String)},
+			"problems": &graphql.Field{Type: nonNull(graphql.Int), Description: "How many published problems the collection has"},
+		},
+	})
+
+	leaderboardEntryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "LeaderboardEntry",
+		Fields: graphql.Fields{
+			"rank":     &graphql.Field{Type: nonNull(graphql.Int)},
+			"playerId": &graphql.Field{Type: nonNull(graphql.ID)},
+			"score":    &graphql.Field{Type: nonNull(graphql.Int)},
+		},
+	})
+
+	leaderboardType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Leaderboard",
+		Fields: graphql.Fields{
+			"period":     &graphql.Field{Type: nonNull(graphql.String)},
+			"periodId":   &graphql.Field{Type: nonNull(graphql.String)},
+			"collection": &graphql.Field{Type: graphql.String},
+			"entries":    &graphql.Field{Type: listOf(leaderboardEntryType)},
+			"me":         &graphql.Field{Type: leaderboardEntryType, Description: "The caller's standing, if they scored in the period"},
+		},
+	})
+
+	leaderboardArgs := graphql.FieldConfigArgument{
+		"period":     &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: services.PeriodAllTime},
+		"collection": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+	}
+
+	labelAccuracyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "LabelAccuracy",
+		Fields: graphql.Fields{
+			"label":    &graphql.Field{Type: nonNull(graphql.String)},
+			"attempts": &graphql.Field{Type: nonNull(graphql.Int)},
+			"correct":  &graphql.Field{Type: nonNull(graphql.Int)},
+			"accuracy": &graphql.Field{Type: nonNull(graphql.Float)},
+		},
+	})
+
+	playerType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Player",
+		Description: "The calling player's score, streaks and accuracy",
+		Fields: graphql.Fields{
+			"playerId":      &graphql.Field{Type: nonNull(graphql.ID)},
+			"totalScore":    &graphql.Field{Type: nonNull(graphql.Int)},
+			"attempts":      &graphql.Field{Type: nonNull(graphql.Int)},
+			"correct":       &graphql.Field{Type: nonNull(graphql.Int)},
+			"accuracy":      &graphql.Field{Type: nonNull(graphql.Float)},
+			"hintsUsed":     &graphql.Field{Type: nonNull(graphql.Int)},
+			"currentStreak": &graphql.Field{Type: nonNull(graphql.Int)},
+			"bestStreak":    &graphql.Field{Type: nonNull(graphql.Int)},
+			"rating":        &graphql.Field{Type: nonNull(graphql.Float)},
+			"labelAccuracy": &graphql.Field{
+				Type: listOf(labelAccuracyType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					stats := sourceAs[models.PlayerStats](p.Source)
+					accuracy := make([]labelAccuracy, 0, len(models.Labels))
+					for _, label := range models.Labels {
+						if labelStats, ok := stats.LabelAccuracy[label]; ok {
+							accuracy = append(accuracy, labelAccuracy{Label: label, Attempts: labelStats.Attempts, Correct: labelStats.Correct, Accuracy: labelStats.Accuracy})
+						}
+					}
+					return accuracy, nil
+				},
+			},
+			"recentAttempts": &graphql.Field{Type: listOf(attemptType)},
+			"rank": &graphql.Field{
+				Type:        leaderboardEntryType,
+				Description: "The player's standing on a leaderboard, if they scored in the period",
+				Args:        leaderboardArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					stats := sourceAs[models.PlayerStats](p.Source)
+					leaderboard, err := s.service.GetLeaderboard(p.Args["period"].(string), p.Args["collection"].(string), stats.PlayerID, 1)
+					if err != nil {
+						return nil, err
+					}
+					return leaderboard.Me, nil
+				},
+			},
+		},
+	})
+
+	// Root fields are nullable, so one failing field leaves the others of a query intact
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"problem": &graphql.Field{
+				Type: problemType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					load := requestFrom(p.Context).loaders.tweets.Load(p.Args["id"].(string))
+					return func() (interface{}, error) {
+						tweet, ok, err := load()
+						if err != nil || !ok || tweet.Status != services.StatusPublished {
+							return nil, err
+						}
+						return models.Problem{ID: tweet.ID, Text: tweet.Text}, nil
+					}, nil
+				},
+			},
+			"problems": &graphql.Field{
+				Type: graphql.NewList(nonNull(problemType)),
+				Args: graphql.FieldConfigArgument{"page": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					problems, err := s.service.GetProblemsByPage(p.Args["page"].(int), s.listPerPage)
+					if problems == nil {
+						problems = []models.Problem{}
+					}
+					return problems, err
+				},
+			},
+			"tweet": &graphql.Field{
+				Type: tweetType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					req := requestFrom(p.Context)
+					if !req.caller.Privileged {
+						return nil, ErrPrivileged
+					}
+					return thunk(req.loaders.tweets.Load(p.Args["id"].(string))), nil
+				},
+			},
+			"tweets": &graphql.Field{
+				Type: graphql.NewList(nonNull(tweetType)),
+				Args: graphql.FieldConfigArgument{"page": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if !requestFrom(p.Context).caller.Privileged {
+						return nil, ErrPrivileged
+					}
+					page := p.Args["page"].(int)
+					if page < 1 {
+						page = 1
+					}
+					tweets, err := s.service.GetTweetsByPage(page, s.listPerPage)
+					if tweets == nil {
+						tweets = []models.Tweet{}
+					}
+					return tweets, err
+				},
+			},
+			"collections": &graphql.Field{
+				Type: graphql.NewList(nonNull(collectionType)),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.service.GetCollections()
+				},
+			},
+			"leaderboard": &graphql.Field{
+				Type: leaderboardType,
+				Args: graphql.FieldConfigArgument{
+					"period":     leaderboardArgs["period"],
+					"collection": leaderboardArgs["collection"],
+					"limit":      &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: s.listPerPage},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit := p.Args["limit"].(int)
+					if limit < 1 || limit > maxLeaderboardSize {
+						return nil, ErrInvalidLimit
+					}
+					player := requestFrom(p.Context).caller.PlayerID
+					return s.service.GetLeaderboard(p.Args["period"].(string), p.Args["collection"].(string), player, limit)
+				},
+			},
+			"me": &graphql.Field{
+				Type:        playerType,
+				Description: "The calling player, identified by the X-Player-ID header",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					player := requestFrom(p.Context).caller.PlayerID
+					if player == "" {
+						return nil, nil
+					}
+					return s.service.GetPlayerStats(player)
+				},
+			},
+		},
+	})
+}
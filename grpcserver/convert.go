@@ -0,0 +1,143 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vibecheck/models"
+	"vibecheck/safety"
+	"vibecheck/services"
+	"vibecheck/vibecheckpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statusError maps service errors to gRPC status codes, like the REST handlers map them
+// to HTTP statuses
+func statusError(err error) error {
+	var rejection *safety.Rejection
+	var duplicate *services.DuplicateError
+	switch {
+	case errors.Is(err, services.ErrTweetNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidStatus), errors.Is(err, services.ErrInvalidPublishAt),
+		errors.Is(err, services.ErrVoteRequiresPlayer), errors.As(err, &rejection):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &duplicate), errors.Is(err, services.ErrDuplicateTweet), errors.Is(err, services.ErrAlreadyAnswered):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, services.ErrDailyProblem):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		// Already a status, e.g. from a failed stream send
+		return err
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func toTweet(tweet *models.Tweet) *vibecheckpb.Tweet {
+	return &vibecheckpb.Tweet{
+		Id:            tweet.ID,
+		Text:          tweet.Text,
+		Hint:          tweet.Hint,
+		HintGenerated: tweet.HintGenerated,
+		Answer:        tweet.Answer,
+		Collection:    tweet.Collection,
+		Status:        tweet.Status,
+		PublishAt:     toTimestamp(tweet.PublishAt),
+	}
+}
+
+func toNewTweet(tweet *models.NewTweet) *vibecheckpb.NewTweet {
+	return &vibecheckpb.NewTweet{
+		Text:       tweet.Text,
+		Hint:       tweet.Hint,
+		Answer:     tweet.Answer,
+		Collection: tweet.Collection,
+		Status:     tweet.Status,
+		PublishAt:  toTimestamp(tweet.PublishAt),
+	}
+}
+
+func fromNewTweet(tweet *vibecheckpb.NewTweet) *models.NewTweet {
+	return &models.NewTweet{
+		Text:       tweet.GetText(),
+		Hint:       tweet.GetHint(),
+		Answer:     tweet.GetAnswer(),
+		Collection: tweet.GetCollection(),
+		Status:     tweet.GetStatus(),
+		PublishAt:  fromTimestamp(tweet.GetPublishAt()),
+	}
+}
+
+func toDuplicates(matches []models.DuplicateMatch) []*vibecheckpb.DuplicateMatch {
+	duplicates := make([]*vibecheckpb.DuplicateMatch, 0, len(matches))
+	for _, match := range matches {
+		duplicates = append(duplicates, &vibecheckpb.DuplicateMatch{
+			TweetId:  match.TweetID,
+			Text:     match.Text,
+			Status:   match.Status,
+			Distance: int32(match.Distance),
+			Exact:    match.Exact,
+		})
+	}
+	return duplicates
+}
+
+func toProblem(problem *models.Problem) *vibecheckpb.Problem {
+	p := &vibecheckpb.Problem{Id: problem.ID, Text: problem.Text}
+	if problem.Difficulty != nil {
+		p.Difficulty = &vibecheckpb.Difficulty{
+			Rating:   problem.Difficulty.Rating,
+			Attempts: int32(problem.Difficulty.Attempts),
+			Level:    problem.Difficulty.Level,
+		}
+	}
+	return p
+}
+
+func toAnswerResponse(result *models.AttemptResult) *vibecheckpb.AnswerProblemResponse {
+	resp := &vibecheckpb.AnswerProblemResponse{
+		Labeling:      result.Labeling,
+		Correct:       result.Correct,
+		Points:        int32(result.Points),
+		HintsUsed:     int32(result.HintsUsed),
+		ResponseMs:    result.ResponseMs,
+		TotalScore:    int32(result.TotalScore),
+		CurrentStreak: int32(result.CurrentStreak),
+		BestStreak:    int32(result.BestStreak),
+		Rating:        result.Rating,
+	}
+	if result.Bot != nil {
+		resp.Bot = &vibecheckpb.BotResult{
+			Model:   result.Bot.Model,
+			Version: result.Bot.Version,
+			Label:   result.Bot.Label,
+			Score:   result.Bot.Score,
+			Correct: result.Bot.Correct,
+			Beat:    result.Bot.Beat,
+		}
+	}
+	return resp
+}
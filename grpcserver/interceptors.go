@@ -0,0 +1,133 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"strings"
+	"time"
+	"vibecheck/services"
+	"vibecheck/vibecheckpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const maxPlayerIDLength = 64
+
+// privilegedMethods return answers and hints or change tweets, so they need the admin
+// token like the /tweets REST routes they mirror
+var privilegedMethods = map[string]bool{
+	vibecheckpb.Vibecheck_ListTweets_FullMethodName:   true,
+	vibecheckpb.Vibecheck_GetTweet_FullMethodName:     true,
+	vibecheckpb.Vibecheck_CreateTweet_FullMethodName:  true,
+	vibecheckpb.Vibecheck_UpdateTweet_FullMethodName:  true,
+	vibecheckpb.Vibecheck_DeleteTweet_FullMethodName:  true,
+	vibecheckpb.Vibecheck_ExportTweets_FullMethodName: true,
+}
+
+// caller is what handlers know about who made the call
+type caller struct {
+	playerID   string
+	sessionID  string
+	privileged bool
+}
+
+// viewerID identifies the caller for no-repeat serving, like the REST viewerID
+func (c caller) viewerID() string {
+	if c.playerID != "" {
+		return services.PlayerViewerID(c.playerID)
+	}
+	if c.sessionID != "" {
+		return services.SessionViewerID(c.sessionID)
+	}
+	return ""
+}
+
+type callerKey struct{}
+
+func callerFrom(ctx context.Context) caller {
+	c, _ := ctx.Value(callerKey{}).(caller)
+	return c
+}
+
+// metadataValue returns the first value of a metadata key, or an empty string
+func metadataValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// identifier returns an x-player-id or x-session-id value, ignoring oversized ones
+func identifier(md metadata.MD, key string) string {
+	id := metadataValue(md, key)
+	if len(id) > maxPlayerIDLength {
+		return ""
+	}
+	return id
+}
+
+type authenticator struct {
+	adminToken string
+}
+
+// authorize checks the admin token of privileged methods and stores the caller in the context
+func (a *authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token, bearer := strings.CutPrefix(metadataValue(md, "authorization"), "Bearer ")
+	admin := bearer && token != "" && a.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) == 1
+	if privilegedMethods[method] && !admin {
+		if !bearer || token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing admin token")
+		}
+		return nil, status.Error(codes.PermissionDenied, "invalid admin token")
+	}
+	c := caller{playerID: identifier(md, "x-player-id"), sessionID: identifier(md, "x-session-id"), privileged: admin}
+	return context.WithValue(ctx, callerKey{}, c), nil
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextStream replaces the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// logUnary logs the method, status code and duration of each call
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s %v\n", info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+// logStream logs the method, status code and duration of each stream
+func logStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	log.Printf("gRPC %s %s %v\n", info.FullMethod, status.Code(err), time.Since(start))
+	return err
+}
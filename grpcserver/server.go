@@ -0,0 +1,198 @@
+// Package grpcserver serves the gRPC API defined in vibecheckpb, for internal services
+// that speak gRPC rather than the REST API. It shares the service layer with the REST
+// routes and runs on its own port.
+package grpcserver
+
+import (
+	"context"
+	"vibecheck/config"
+	"vibecheck/models"
+	"vibecheck/services"
+	"vibecheck/vibecheckpb"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type server struct {
+	vibecheckpb.UnimplementedVibecheckServer
+	service     *services.VibecheckService
+	listPerPage int
+}
+
+// New creates a gRPC server over service, with the auth and logging interceptors
+func New(service *services.VibecheckService, cfg config.Config) *grpc.Server {
+	auth := &authenticator{adminToken: cfg.AdminToken}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logUnary, auth.unary),
+		grpc.ChainStreamInterceptor(logStream, auth.stream),
+	)
+	vibecheckpb.RegisterVibecheckServer(grpcServer, &server{service: service, listPerPage: cfg.ListPerPage})
+	return grpcServer
+}
+
+// validTweetID rejects IDs no tweet can have before they reach the database
+func validTweetID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return status.Error(codes.NotFound, services.ErrTweetNotFound.Error())
+	}
+	return nil
+}
+
+func (s *server) ListTweets(ctx context.Context, req *vibecheckpb.ListTweetsRequest) (*vibecheckpb.ListTweetsResponse, error) {
+	tweets, err := s.service.GetTweetsByPage(int(req.Page), s.listPerPage)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &vibecheckpb.ListTweetsResponse{Tweets: make([]*vibecheckpb.Tweet, 0, len(tweets))}
+	for i := range tweets {
+		resp.Tweets = append(resp.Tweets, toTweet(&tweets[i]))
+	}
+	return resp, nil
+}
+
+func (s *server) GetTweet(ctx context.Context, req *vibecheckpb.GetTweetRequest) (*vibecheckpb.Tweet, error) {
+	if err := validTweetID(req.Id); err != nil {
+		return nil, err
+	}
+	tweet, err := s.service.GetTweet(req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if tweet == nil {
+		return nil, statusError(services.ErrTweetNotFound)
+	}
+	return toTweet(tweet), nil
+}
+
+func (s *server) CreateTweet(ctx context.Context, req *vibecheckpb.CreateTweetRequest) (*vibecheckpb.CreateTweetResponse, error) {
+	tweet := fromNewTweet(req.Tweet)
+	duplicates, err := s.service.NewTweet(tweet)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &vibecheckpb.CreateTweetResponse{Tweet: toNewTweet(tweet), Duplicates: toDuplicates(duplicates)}, nil
+}
+
+func (s *server) UpdateTweet(ctx context.Context, req *vibecheckpb.UpdateTweetRequest) (*vibecheckpb.Tweet, error) {
+	if err := validTweetID(req.Id); err != nil {
+		return nil, err
+	}
+	tweet := &models.Tweet{ID: req.Id, Text: req.Text, Hint: req.Hint, Answer: req.Answer, Collection: req.Collection}
+	if err := s.service.UpdateTweet(tweet); err != nil {
+		return nil, statusError(err)
+	}
+	if tweet.Status == "" {
+		// No tweet was updated
+		return nil, statusError(services.ErrTweetNotFound)
+	}
+	return toTweet(tweet), nil
+}
+
+func (s *server) DeleteTweet(ctx context.Context, req *vibecheckpb.DeleteTweetRequest) (*vibecheckpb.DeleteTweetResponse, error) {
+	if err := validTweetID(req.Id); err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteTweet(req.Id); err != nil {
+		return nil, statusError(err)
+	}
+	return &vibecheckpb.DeleteTweetResponse{}, nil
+}
+
+func (s *server) ExportTweets(req *vibecheckpb.ExportTweetsRequest, stream grpc.ServerStreamingServer[vibecheckpb.Tweet]) error {
+	err := s.service.ExportTweets(stream.Context(), req.Status, req.Collection, func(tweet *models.Tweet) error {
+		return stream.Send(toTweet(tweet))
+	})
+	if err != nil {
+		return statusError(err)
+	}
+	return nil
+}
+
+func (s *server) ListProblems(ctx context.Context, req *vibecheckpb.ListProblemsRequest) (*vibecheckpb.ListProblemsResponse, error) {
+	problems, err := s.service.GetProblemsByPage(int(req.Page), s.listPerPage)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &vibecheckpb.ListProblemsResponse{Problems: make([]*vibecheckpb.Problem, 0, len(problems))}
+	for i := range problems {
+		resp.Problems = append(resp.Problems, toProblem(&problems[i]))
+	}
+	return resp, nil
+}
+
+func (s *server) GetProblem(ctx context.Context, req *vibecheckpb.GetProblemRequest) (*vibecheckpb.Problem, error) {
+	if err := validTweetID(req.Id); err != nil {
+		return nil, err
+	}
+	problem, err := s.service.GetProblem(req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	problem.Difficulty, err = s.service.GetDifficulty(problem.ID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	s.service.MarkServed(callerFrom(ctx).playerID, problem.ID)
+	return toProblem(problem), nil
+}
+
+func (s *server) CreateProblem(ctx context.Context, req *vibecheckpb.CreateProblemRequest) (*vibecheckpb.CreateProblemResponse, error) {
+	problem := fromNewTweet(req.Problem)
+	duplicates, err := s.service.NewProblem(problem)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	problem.Status = services.StatusPending
+	resp := &vibecheckpb.CreateProblemResponse{Problem: toNewTweet(problem)}
+	// Players are not shown other tweets, which may not be published
+	if callerFrom(ctx).privileged {
+		resp.Duplicates = toDuplicates(duplicates)
+	}
+	return resp, nil
+}
+
+func (s *server) GetQuizProblem(ctx context.Context, req *vibecheckpb.GetQuizProblemRequest) (*vibecheckpb.Problem, error) {
+	caller := callerFrom(ctx)
+	opts := services.QuizOptions{
+		ViewerID:   caller.viewerID(),
+		PlayerID:   caller.playerID,
+		ModelWrong: req.ModelWrong,
+		Collection: req.Collection,
+	}
+	problem, err := s.service.GetRandomProblem(opts)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	s.service.MarkServed(caller.playerID, problem.ID)
+	return toProblem(problem), nil
+}
+
+func (s *server) AnswerProblem(ctx context.Context, req *vibecheckpb.AnswerProblemRequest) (*vibecheckpb.AnswerProblemResponse, error) {
+	if !models.IsValidLabel(req.Guess) {
+		return nil, status.Error(codes.InvalidArgument, "invalid guess")
+	}
+	if err := validTweetID(req.Id); err != nil {
+		return nil, err
+	}
+	attempt := &models.AttemptSolution{ID: req.Id, Guess: req.Guess, Bot: req.Bot}
+	result, err := s.service.SubmitAnswer(callerFrom(ctx).playerID, attempt)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toAnswerResponse(result), nil
+}
+
+func (s *server) GetHint(ctx context.Context, req *vibecheckpb.GetHintRequest) (*vibecheckpb.GetHintResponse, error) {
+	if err := validTweetID(req.TweetId); err != nil {
+		return nil, err
+	}
+	hint, err := s.service.GetHint(req.TweetId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	s.service.RecordHintUsed(callerFrom(ctx).playerID, req.TweetId)
+	return &vibecheckpb.GetHintResponse{Hint: hint}, nil
+}
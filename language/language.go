@@ -0,0 +1,102 @@
+// Package language guesses the language of short texts. Texts in a non-Latin script are
+// identified by their script, Latin texts by the stopwords they contain.
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Unknown is reported when no language stands out
+const Unknown = "unknown"
+
+// stopwords holds frequent short words of each Latin-script language
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "was", "to", "of", "in", "it", "that", "this", "for", "you", "with", "my", "have", "not", "but", "on", "be", "so", "just", "at", "me", "what", "i"},
+	"es": {"el", "la", "los", "las", "que", "de", "y", "en", "es", "por", "para", "con", "no", "una", "un", "muy", "pero", "mi", "lo", "se", "del", "al", "como", "yo"},
+	"fr": {"le", "la", "les", "et", "est", "un", "une", "des", "du", "que", "pas", "je", "tu", "il", "elle", "nous", "vous", "pour", "dans", "avec", "mais", "ce", "c'est", "très"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "du", "ein", "eine", "zu", "mit", "auf", "für", "aber", "sehr", "es", "wir", "sie", "den", "dem", "auch", "noch"},
+	"pt": {"o", "a", "os", "as", "que", "de", "e", "em", "um", "uma", "não", "muito", "para", "com", "mas", "eu", "você", "do", "da", "está", "isso", "por"},
+	"it": {"il", "lo", "la", "gli", "le", "che", "di", "e", "è", "un", "una", "non", "per", "con", "ma", "sono", "molto", "io", "del", "della", "questo", "anche"},
+	"nl": {"de", "het", "een", "en", "is", "niet", "ik", "je", "van", "dat", "op", "te", "met", "maar", "zijn", "voor", "heel", "ook", "wat", "er"},
+}
+
+// scripts names the language reported for texts written mostly in a non-Latin script
+var scripts = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Arabic, "ar"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+var lookup = func() map[string][]string {
+	index := map[string][]string{}
+	for language, words := range stopwords {
+		for _, word := range words {
+			index[word] = append(index[word], language)
+		}
+	}
+	return index
+}()
+
+// Detect returns the ISO 639-1 code of the language a text is most likely written in, or Unknown
+func Detect(text string) string {
+	letters := 0
+	counts := map[string]int{}
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, script := range scripts {
+			if unicode.Is(script.table, r) {
+				counts[script.language]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return Unknown
+	}
+	// Japanese mixes kana with Han characters, so any kana settles it
+	if counts["ja"] > 0 {
+		return "ja"
+	}
+	for _, script := range scripts {
+		if counts[script.language]*2 > letters {
+			return script.language
+		}
+	}
+
+	hits := map[string]int{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\'' && r != '’'
+	}) {
+		for _, language := range lookup[strings.ReplaceAll(word, "’", "'")] {
+			hits[language]++
+		}
+	}
+
+	best, bestHits, tied := Unknown, 0, false
+	for _, language := range []string{"en", "es", "fr", "de", "pt", "it", "nl"} {
+		switch {
+		case hits[language] > bestHits:
+			best, bestHits, tied = language, hits[language], false
+		case hits[language] == bestHits && bestHits > 0:
+			tied = true
+		}
+	}
+	if bestHits == 0 || tied {
+		return Unknown
+	}
+	return best
+}
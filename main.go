@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"net"
 	"vibecheck/config"
+	"vibecheck/grpcserver"
 	"vibecheck/routes"
+	"vibecheck/services"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -15,10 +18,10 @@ import (
 
 func main() {
 	cfg := config.LoadConfig()
-	log.Printf("Loaded config: %+v\n", cfg)
+	log.Printf("Loaded config: %s\n", cfg)
 
 	connStr := "host=" + cfg.DB.Host + " port=" + cfg.DB.Port + " user=" + cfg.DB.User + " password=" + cfg.DB.Password + " dbname=" + cfg.DB.Database + " sslmode=disable"
-	log.Printf("Connecting to PostgreSQL with connection string: %s\n", connStr)
+	log.Printf("Connecting to PostgreSQL at %s:%s as %s, database %s\n", cfg.DB.Host, cfg.DB.Port, cfg.DB.User, cfg.DB.Database)
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
 		log.Fatal(err)
@@ -36,6 +39,36 @@ func main() {
 	}
 	defer redisClient.Close()
 
+	vibecheckService := services.NewVibecheckService(db, redisClient, cfg)
+	go func() {
+		if filled, err := vibecheckService.BackfillFingerprints(); err != nil {
+			log.Printf("Fingerprint backfill failed: %v\n", err)
+		} else {
+			log.Printf("Fingerprinted %d tweets\n", filled)
+		}
+		if classified, err := vibecheckService.BackfillPredictions(); err != nil {
+			log.Printf("Prediction backfill failed: %v\n", err)
+		} else {
+			log.Printf("Classified %d tweets\n", classified)
+		}
+	}()
+	go vibecheckService.RunDisagreementScanner(context.Background())
+	go vibecheckService.RunScheduler(context.Background())
+	go vibecheckService.RunWebhookDispatcher(context.Background())
+	go vibecheckService.RunOutboxRelay(context.Background())
+
+	if cfg.GRPCPort != "" {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatal(err)
+		}
+		go func() {
+			if err := grpcserver.New(vibecheckService, cfg).Serve(lis); err != nil {
+				log.Printf("gRPC server stopped: %v\n", err)
+			}
+		}()
+	}
+
 	r := gin.Default()
 
 	corsConfig := cors.DefaultConfig()
@@ -46,10 +79,12 @@ func main() {
 	corsConfig.AddAllowHeaders("Accept")
 	corsConfig.AddAllowHeaders("Origin")
 	corsConfig.AddAllowHeaders("X-CSRF-Token")
+	corsConfig.AddAllowHeaders("X-Player-ID")
+	corsConfig.AddAllowHeaders("X-Session-ID")
 
 	r.Use(cors.New(corsConfig))
 
-	routes.SetupRoutes(r, db, redisClient, cfg.ListPerPage)
+	routes.SetupRoutes(r, vibecheckService, cfg)
 
 	r.Run(":" + cfg.ServicePort)
 }
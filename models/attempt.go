@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type Attempt struct {
+	ID         string    `json:"id"`
+	TweetID    string    `json:"tweetId"`
+	Guess      string    `json:"guess"`
+	Answer     string    `json:"answer"`
+	Correct    bool      `json:"correct"`
+	HintsUsed  int       `json:"hintsUsed"`
+	ResponseMs int64     `json:"responseMs"`
+	Points     int       `json:"points"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type AttemptResult struct {
+	Labeling      bool       `json:"labeling,omitempty"`
+	Repeat        bool       `json:"repeat,omitempty"`
+	Correct       bool       `json:"correct"`
+	Points        int        `json:"points"`
+	HintsUsed     int        `json:"hintsUsed"`
+	ResponseMs    int64      `json:"responseMs"`
+	TotalScore    int        `json:"totalScore"`
+	CurrentStreak int        `json:"currentStreak"`
+	BestStreak    int        `json:"bestStreak"`
+	Rating        float64    `json:"rating,omitempty"`
+	Bot           *BotResult `json:"bot,omitempty"`
+}
+
+type LabelStats struct {
+	Attempts int     `json:"attempts"`
+	Correct  int     `json:"correct"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+type PlayerStats struct {
+	PlayerID       string                `json:"playerId"`
+	TotalScore     int                   `json:"totalScore"`
+	Attempts       int                   `json:"attempts"`
+	Correct        int                   `json:"correct"`
+	Accuracy       float64               `json:"accuracy"`
+	HintsUsed      int                   `json:"hintsUsed"`
+	CurrentStreak  int                   `json:"currentStreak"`
+	BestStreak     int                   `json:"bestStreak"`
+	Rating         float64               `json:"rating"`
+	LabelAccuracy  map[string]LabelStats `json:"labelAccuracy"`
+	RecentAttempts []Attempt             `json:"recentAttempts"`
+}
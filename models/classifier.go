@@ -0,0 +1,18 @@
+package models
+
+type ClassifierInfo struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	BatchSize int    `json:"batchSize,omitempty"`
+	External  bool   `json:"external"`
+}
+
+type ModelComparison struct {
+	Model         string                `json:"model"`
+	Version       string                `json:"version"`
+	Scored        int                   `json:"scored"`
+	Gold          int                   `json:"gold"`
+	Correct       int                   `json:"correct"`
+	Accuracy      float64               `json:"accuracy"`
+	LabelAccuracy map[string]LabelStats `json:"labelAccuracy"`
+}
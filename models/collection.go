@@ -0,0 +1,6 @@
+package models
+
+type Collection struct {
+	Name     string `json:"name"`
+	Problems int    `json:"problems"`
+}
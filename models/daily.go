@@ -0,0 +1,22 @@
+package models
+
+type DailyChallenge struct {
+	Day      string    `json:"day"`
+	Problems []Problem `json:"problems"`
+}
+
+type DailyProblemResult struct {
+	ID       string         `json:"id"`
+	Text     string         `json:"text"`
+	Answer   string         `json:"answer,omitempty"`
+	Attempts int            `json:"attempts"`
+	Correct  int            `json:"correct"`
+	Accuracy float64        `json:"accuracy"`
+	Guesses  map[string]int `json:"guesses"`
+}
+
+type DailyResults struct {
+	Day      string               `json:"day"`
+	Players  int                  `json:"players"`
+	Problems []DailyProblemResult `json:"problems"`
+}
@@ -0,0 +1,21 @@
+package models
+
+type DuplicateMatch struct {
+	TweetID  string `json:"tweetId"`
+	Text     string `json:"text"`
+	Status   string `json:"status"`
+	Distance int    `json:"distance"`
+	Exact    bool   `json:"exact"`
+}
+
+type DuplicateTweet struct {
+	ID     string `json:"id"`
+	Text   string `json:"text"`
+	Status string `json:"status"`
+}
+
+type DuplicateCluster struct {
+	Exact       bool             `json:"exact"`
+	MaxDistance int              `json:"maxDistance"`
+	Tweets      []DuplicateTweet `json:"tweets"`
+}
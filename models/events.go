@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is a live game event, identified by its position in the event stream
+type Event struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+	At   time.Time       `json:"at"`
+}
+
+type ProblemPublishedEvent struct {
+	TweetID    string `json:"tweetId"`
+	Text       string `json:"text"`
+	Collection string `json:"collection,omitempty"`
+}
+
+type LeaderboardEvent struct {
+	PlayerID   string `json:"playerId"`
+	Points     int    `json:"points"`
+	Collection string `json:"collection,omitempty"`
+	DailyScore int    `json:"dailyScore"`
+	DailyRank  int    `json:"dailyRank"`
+}
+
+type DailyLiveEvent struct {
+	Day      string `json:"day"`
+	Problems int    `json:"problems"`
+}
+
+type DailyAnswersEvent struct {
+	Day     string         `json:"day"`
+	TweetID string         `json:"tweetId"`
+	Counts  map[string]int `json:"counts"`
+	Total   int            `json:"total"`
+}
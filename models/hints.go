@@ -0,0 +1,12 @@
+package models
+
+type GeneratedHint struct {
+	TweetID string `json:"tweetId"`
+	Text    string `json:"text"`
+	Hint    string `json:"hint"`
+	Status  string `json:"status"`
+}
+
+type HintUpdate struct {
+	Hint string `json:"hint"`
+}
@@ -1,23 +1,39 @@
 package models
 
+import "time"
+
 type Tweet struct {
-	ID     string `json:"id"`
-	Text   string `json:"text"`
-	Hint   string `json:"hint"`
-	Answer string `json:"answer"`
+	ID            string     `json:"id"`
+	Text          string     `json:"text"`
+	Hint          string     `json:"hint"`
+	HintGenerated bool       `json:"hintGenerated,omitempty"`
+	Answer        string     `json:"answer"`
+	Collection    string     `json:"collection"`
+	Status        string     `json:"status"`
+	PublishAt     *time.Time `json:"publishAt,omitempty"`
 }
 
 type NewTweet struct {
-	Text   string `json:"text"`
-	Hint   string `json:"hint"`
-	Answer string `json:"answer"`
+	Text       string     `json:"text"`
+	Hint       string     `json:"hint"`
+	Answer     string     `json:"answer"`
+	Collection string     `json:"collection"`
+	Status     string     `json:"status,omitempty"`
+	PublishAt  *time.Time `json:"publishAt,omitempty"`
 }
 
 type NewProblem = NewTweet
 
 type Problem struct {
-	ID   string `json:"id"`
-	Text string `json:"text"`
+	ID         string      `json:"id"`
+	Text       string      `json:"text"`
+	Difficulty *Difficulty `json:"difficulty,omitempty"`
+}
+
+type Difficulty struct {
+	Rating   float64 `json:"rating"`
+	Attempts int     `json:"attempts"`
+	Level    string  `json:"level"`
 }
 
 type HintContent struct {
@@ -28,4 +44,23 @@ type HintContent struct {
 type AttemptSolution struct {
 	ID    string `json:"id"`
 	Guess string `json:"guess"`
+	Bot   bool   `json:"bot"`
+}
+
+// Labels lists the sentiment labels a tweet can be answered with
+var Labels = []string{"positive", "negative", "neutral"}
+
+// IsValidLabel reports whether label is one of the known sentiment labels
+func IsValidLabel(label string) bool {
+	for _, l := range Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+type StatusChange struct {
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publishAt"`
 }
@@ -0,0 +1,25 @@
+package models
+
+type LabelingItem struct {
+	TweetID       string             `json:"tweetId"`
+	Text          string             `json:"text"`
+	Votes         map[string]int     `json:"votes"`
+	TotalVotes    int                `json:"totalVotes"`
+	Weights       map[string]float64 `json:"weights"`
+	Consensus     string             `json:"consensus,omitempty"`
+	Confidence    float64            `json:"confidence"`
+	ItemAgreement float64            `json:"itemAgreement"`
+	Kappa         float64            `json:"kappa"`
+}
+
+type LabelingReport struct {
+	Rule              string  `json:"rule"`
+	Items             int     `json:"items"`
+	RatedItems        int     `json:"ratedItems"`
+	Votes             int     `json:"votes"`
+	ConsensusItems    int     `json:"consensusItems"`
+	ObservedAgreement float64 `json:"observedAgreement"`
+	ExpectedAgreement float64 `json:"expectedAgreement"`
+	FleissKappa       float64 `json:"fleissKappa"`
+	KrippendorffAlpha float64 `json:"krippendorffAlpha"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type LeaderboardEntry struct {
+	Rank     int64  `json:"rank"`
+	PlayerID string `json:"playerId"`
+	Score    int64  `json:"score"`
+}
+
+type Leaderboard struct {
+	Period     string             `json:"period"`
+	PeriodID   string             `json:"periodId"`
+	Collection string             `json:"collection,omitempty"`
+	Entries    []LeaderboardEntry `json:"entries"`
+	Me         *LeaderboardEntry  `json:"me,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+	"vibecheck/safety"
+)
+
+type Submission struct {
+	ID          string          `json:"id"`
+	Text        string          `json:"text"`
+	Hint        string          `json:"hint"`
+	Answer      string          `json:"answer"`
+	Collection  string          `json:"collection"`
+	Status      string          `json:"status"`
+	Flags       []safety.Reason `json:"flags,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+	ModeratedBy string          `json:"moderatedBy,omitempty"`
+	ModeratedAt *time.Time      `json:"moderatedAt,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+type ModerationDecision struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// TweetStatusChangedEvent is the outbox payload of a tweet moving through its lifecycle
+type TweetStatusChangedEvent struct {
+	TweetID   string     `json:"tweetId"`
+	From      string     `json:"from"`
+	To        string     `json:"to"`
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "vibecheck/sentiment"
+
+type Prediction struct {
+	TweetID string           `json:"tweetId"`
+	Model   string           `json:"model"`
+	Version string           `json:"version"`
+	Label   string           `json:"label"`
+	Score   float64          `json:"score"`
+	Answer  string           `json:"answer,omitempty"`
+	Correct *bool            `json:"correct,omitempty"`
+	Words   []sentiment.Word `json:"words,omitempty"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+type LabelCount struct {
+	Label string  `json:"label"`
+	Count int     `json:"count"`
+	Share float64 `json:"share"`
+}
+
+type HintCoverage struct {
+	WithHint  int     `json:"withHint"`
+	Generated int     `json:"generated"`
+	Curated   int     `json:"curated"`
+	Coverage  float64 `json:"coverage"`
+}
+
+type LengthBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max,omitempty"`
+	Count int `json:"count"`
+}
+
+type DuplicateRate struct {
+	ExactDuplicates int     `json:"exactDuplicates"`
+	NearDuplicates  int     `json:"nearDuplicates"`
+	Clusters        int     `json:"clusters"`
+	Rate            float64 `json:"rate"`
+}
+
+type LanguageCount struct {
+	Language string  `json:"language"`
+	Count    int     `json:"count"`
+	Share    float64 `json:"share"`
+}
+
+type HardItem struct {
+	TweetID  string  `json:"tweetId"`
+	Text     string  `json:"text"`
+	Answer   string  `json:"answer"`
+	Attempts int     `json:"attempts"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+type DatasetReport struct {
+	GeneratedAt     time.Time       `json:"generatedAt"`
+	Status          string          `json:"status,omitempty"`
+	Tweets          int             `json:"tweets"`
+	Statuses        map[string]int  `json:"statuses"`
+	Labels          []LabelCount    `json:"labels"`
+	Hints           HintCoverage    `json:"hints"`
+	MeanLength      float64         `json:"meanLength"`
+	LengthHistogram []LengthBucket  `json:"lengthHistogram"`
+	Duplicates      DuplicateRate   `json:"duplicates"`
+	Languages       []LanguageCount `json:"languages"`
+	HardestItems    []HardItem      `json:"hardestItems"`
+}
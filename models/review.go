@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type ReviewItem struct {
+	TweetID        string         `json:"tweetId"`
+	Text           string         `json:"text"`
+	GoldLabel      string         `json:"goldLabel"`
+	SuggestedLabel string         `json:"suggestedLabel"`
+	Disagreement   float64        `json:"disagreement"`
+	Attempts       int            `json:"attempts"`
+	Guesses        map[string]int `json:"guesses"`
+	Status         string         `json:"status"`
+	DecisionLabel  string         `json:"decisionLabel,omitempty"`
+	DecidedBy      string         `json:"decidedBy,omitempty"`
+	DecisionNote   string         `json:"decisionNote,omitempty"`
+	FlaggedAt      time.Time      `json:"flaggedAt"`
+	DecidedAt      *time.Time     `json:"decidedAt,omitempty"`
+}
+
+type ReviewDecision struct {
+	Label string `json:"label"`
+	Note  string `json:"note"`
+}
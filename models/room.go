@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+type NewRoom struct {
+	Size             int    `json:"size"`
+	CountdownSeconds int    `json:"countdownSeconds"`
+	Collection       string `json:"collection,omitempty"`
+}
+
+type RoomGuess struct {
+	Guess string `json:"guess" binding:"required"`
+}
+
+type RoomPlayer struct {
+	PlayerID string `json:"playerId"`
+	Score    int    `json:"score"`
+	Answered bool   `json:"answered"`
+}
+
+type Room struct {
+	Code             string       `json:"code"`
+	HostID           string       `json:"hostId"`
+	Status           string       `json:"status"`
+	Size             int          `json:"size"`
+	CountdownSeconds int          `json:"countdownSeconds"`
+	Collection       string       `json:"collection,omitempty"`
+	Position         int          `json:"position"`
+	Problem          *Problem     `json:"problem,omitempty"`
+	Deadline         *time.Time   `json:"deadline,omitempty"`
+	Players          []RoomPlayer `json:"players"`
+	LastResult       *RoomResult  `json:"lastResult,omitempty"`
+	CreatedAt        time.Time    `json:"createdAt"`
+}
+
+type RoomAnswer struct {
+	PlayerID string `json:"playerId"`
+	Guess    string `json:"guess"`
+	Correct  bool   `json:"correct"`
+	Points   int    `json:"points"`
+}
+
+type RoomResult struct {
+	Position int            `json:"position"`
+	TweetID  string         `json:"tweetId"`
+	Text     string         `json:"text"`
+	Answer   string         `json:"answer"`
+	Counts   map[string]int `json:"counts"`
+	Answers  []RoomAnswer   `json:"answers"`
+	Scores   []RoomPlayer   `json:"scores"`
+}
+
+// RoomEvent is broadcast to everyone in a room; only the fields relevant to its type are set
+type RoomEvent struct {
+	Type     string       `json:"type"`
+	Code     string       `json:"code"`
+	PlayerID string       `json:"playerId,omitempty"`
+	Position int          `json:"position"`
+	Problem  *Problem     `json:"problem,omitempty"`
+	Deadline *time.Time   `json:"deadline,omitempty"`
+	Answered int          `json:"answered,omitempty"`
+	Players  int          `json:"players,omitempty"`
+	Result   *RoomResult  `json:"result,omitempty"`
+	Scores   []RoomPlayer `json:"scores,omitempty"`
+	Room     *Room        `json:"room,omitempty"`
+	Error    string       `json:"error,omitempty"`
+	At       time.Time    `json:"at"`
+}
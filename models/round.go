@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+type NewRound struct {
+	Size             int    `json:"size"`
+	TimeLimitSeconds int    `json:"timeLimitSeconds"`
+	Collection       string `json:"collection,omitempty"`
+}
+
+type Round struct {
+	ID               string        `json:"id"`
+	PlayerID         string        `json:"playerId"`
+	Size             int           `json:"size"`
+	TimeLimitSeconds int           `json:"timeLimitSeconds"`
+	StartedAt        time.Time     `json:"startedAt"`
+	EndsAt           time.Time     `json:"endsAt"`
+	ClosedAt         *time.Time    `json:"closedAt,omitempty"`
+	Score            int           `json:"score"`
+	Problems         []Problem     `json:"problems,omitempty"`
+	Summary          *RoundSummary `json:"summary,omitempty"`
+}
+
+type RoundItemResult struct {
+	Position int    `json:"position"`
+	TweetID  string `json:"tweetId"`
+	Text     string `json:"text"`
+	Guess    string `json:"guess,omitempty"`
+	Answer   string `json:"answer"`
+	Answered bool   `json:"answered"`
+	Correct  bool   `json:"correct"`
+	Points   int    `json:"points"`
+	TimeMs   int64  `json:"timeMs"`
+}
+
+type RoundSummary struct {
+	Score      int               `json:"score"`
+	Answered   int               `json:"answered"`
+	Correct    int               `json:"correct"`
+	Accuracy   float64           `json:"accuracy"`
+	DurationMs int64             `json:"durationMs"`
+	Items      []RoundItemResult `json:"items"`
+	Mistakes   []RoundItemResult `json:"mistakes"`
+}
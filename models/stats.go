@@ -0,0 +1,11 @@
+package models
+
+type ProblemStats struct {
+	TweetID          string         `json:"tweetId"`
+	Attempts         int            `json:"attempts"`
+	Correct          int            `json:"correct"`
+	Accuracy         float64        `json:"accuracy"`
+	Guesses          map[string]int `json:"guesses"`
+	MedianResponseMs float64        `json:"medianResponseMs"`
+	HintUsageRate    float64        `json:"hintUsageRate"`
+}
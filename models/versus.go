@@ -0,0 +1,33 @@
+package models
+
+type BotResult struct {
+	Model   string  `json:"model"`
+	Version string  `json:"version"`
+	Label   string  `json:"label"`
+	Score   float64 `json:"score"`
+	Correct bool    `json:"correct"`
+	Beat    bool    `json:"beat"`
+}
+
+type VersusStats struct {
+	Attempts      int     `json:"attempts"`
+	HumanCorrect  int     `json:"humanCorrect"`
+	ModelCorrect  int     `json:"modelCorrect"`
+	HumanAccuracy float64 `json:"humanAccuracy"`
+	ModelAccuracy float64 `json:"modelAccuracy"`
+	BeatModel     int     `json:"beatModel"`
+	LostToModel   int     `json:"lostToModel"`
+}
+
+type CollectionVersus struct {
+	Collection string `json:"collection"`
+	VersusStats
+}
+
+type Versus struct {
+	PlayerID    string             `json:"playerId,omitempty"`
+	Model       string             `json:"model"`
+	Version     string             `json:"version"`
+	Overall     VersusStats        `json:"overall"`
+	Collections []CollectionVersus `json:"collections"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+	"vibecheck/safety"
+)
+
+type NewWebhook struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+// Webhook is an endpoint notified of events. Its secret is only returned when it is registered.
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhookId"`
+	EventID        string          `json:"eventId"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`
+	ResponseStatus *int            `json:"responseStatus,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+// WebhookPayload is the signed body posted to webhooks
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+type TweetDeletedEvent struct {
+	TweetID string `json:"tweetId"`
+}
+
+type TweetFlaggedEvent struct {
+	TweetID string          `json:"tweetId"`
+	Reason  string          `json:"reason"`
+	Flags   []safety.Reason `json:"flags,omitempty"`
+	Review  *FlaggedLabel   `json:"review,omitempty"`
+}
+
+type FlaggedLabel struct {
+	GoldLabel      string  `json:"goldLabel"`
+	SuggestedLabel string  `json:"suggestedLabel"`
+	Disagreement   float64 `json:"disagreement"`
+	Attempts       int     `json:"attempts"`
+}
@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTP POSTs each event as JSON to an endpoint, which must answer with a 2xx status
+type HTTP struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTP creates a sink posting to url
+func NewHTTP(url string, timeout time.Duration) *HTTP {
+	return &HTTP{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (h *HTTP) Name() string {
+	return "webhook"
+}
+
+func (h *HTTP) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRejected, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", event.ID)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode <= 499 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		// The endpoint will refuse the event however often it is sent
+		return fmt.Errorf("%w: outbox endpoint responded %d", ErrRejected, resp.StatusCode)
+	}
+	return fmt.Errorf("outbox endpoint responded %d", resp.StatusCode)
+}
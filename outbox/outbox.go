@@ -0,0 +1,46 @@
+// Package outbox publishes domain events recorded in the outbox table to a sink. Events
+// are written in the same transaction as the change they describe and relayed afterwards,
+// so every committed change is eventually published, at least once.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrRejected marks failures retrying will not fix, like an endpoint refusing the event,
+// so the relay sets the event aside instead of retrying it
+var ErrRejected = errors.New("event rejected by the sink")
+
+// Event is a domain event about an aggregate, like a tweet
+type Event struct {
+	ID          string          `json:"id"`
+	Aggregate   string          `json:"aggregate"`
+	AggregateID string          `json:"aggregateId"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+// Sink receives relayed events. An event may be published more than once when the relay
+// fails after publishing it, so consumers should deduplicate on the event ID. Errors
+// wrapping ErrRejected are not retried.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event Event) error
+}
+
+// Log writes events to the application log, for development
+type Log struct{}
+
+func (Log) Name() string {
+	return "log"
+}
+
+func (Log) Publish(ctx context.Context, event Event) error {
+	log.Printf("Outbox event %s: %s %s/%s %s\n", event.ID, event.Type, event.Aggregate, event.AggregateID, event.Payload)
+	return nil
+}
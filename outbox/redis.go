@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStream appends events to a Redis stream, trimmed to about maxLen entries
+type RedisStream struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStream creates a sink appending to the given stream
+func NewRedisStream(client *redis.Client, stream string, maxLen int64) *RedisStream {
+	return &RedisStream{client: client, stream: stream, maxLen: maxLen}
+}
+
+func (r *RedisStream) Name() string {
+	return "redis"
+}
+
+func (r *RedisStream) Publish(ctx context.Context, event Event) error {
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.stream,
+		MaxLen: r.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"id":          event.ID,
+			"aggregate":   event.Aggregate,
+			"aggregateId": event.AggregateID,
+			"type":        event.Type,
+			"payload":     string(event.Payload),
+			"createdAt":   event.CreatedAt.Format(time.RFC3339Nano),
+		},
+	}).Err()
+}
@@ -1,24 +1,28 @@
 package routes
 
 import (
-	"database/sql"
+	"vibecheck/config"
 	"vibecheck/controllers"
+	"vibecheck/services"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 )
 
-func SetupRoutes(router *gin.Engine, db *sql.DB, redisClient *redis.Client, listPerPage int) {
-	vibecheckController := controllers.NewVibecheckController(db, redisClient, listPerPage)
+func SetupRoutes(router *gin.Engine, vibecheckService *services.VibecheckService, cfg config.Config) {
+	vibecheckController := controllers.NewVibecheckController(vibecheckService, cfg)
 
-	// Dev routes
-	router.GET("/tweets", vibecheckController.GetTweets) // For testing purposes
-	router.GET("/tweets/page/:pageNumber", vibecheckController.GetTweetsByPage)
+	// Dev routes, which return answers or change tweets, require the admin token
+	router.GET("/tweets", vibecheckController.RequireAdmin, vibecheckController.GetTweets) // For testing purposes
+	router.GET("/tweets/page/:pageNumber", vibecheckController.RequireAdmin, vibecheckController.GetTweetsByPage)
 
-	router.POST("/tweets/create", vibecheckController.NewTweet)
-	router.PUT("/tweets/:id", vibecheckController.UpdateTweet)
-	router.GET("/tweets/:id", vibecheckController.GetTweet)
-	router.DELETE("/tweets/:id", vibecheckController.DeleteTweet)
+	router.POST("/tweets/create", vibecheckController.RequireAdmin, vibecheckController.NewTweet)
+	router.PUT("/tweets/:id", vibecheckController.RequireAdmin, vibecheckController.UpdateTweet)
+	router.GET("/tweets/:id", vibecheckController.RequireAdmin, vibecheckController.GetTweet)
+	router.DELETE("/tweets/:id", vibecheckController.RequireAdmin, vibecheckController.DeleteTweet)
+	router.GET("/tweets/:id/stats", vibecheckController.RequireAdmin, vibecheckController.GetTweetStats)
+	router.POST("/tweets/:id/status", vibecheckController.RequireAdmin, vibecheckController.ChangeTweetStatus)
+	router.GET("/tweets/:id/prediction", vibecheckController.RequireAdmin, vibecheckController.GetPrediction)
+	router.GET("/tweets/:id/predictions", vibecheckController.RequireAdmin, vibecheckController.GetTweetPredictions)
 
 	// User routes
 
@@ -28,7 +32,73 @@ func SetupRoutes(router *gin.Engine, db *sql.DB, redisClient *redis.Client, list
 
 	// Gameplay routes
 	router.GET("/problem/:id", vibecheckController.GetProblem)
+	router.GET("/problem/:id/stats", vibecheckController.GetProblemStats)
 	router.GET("/problem/quiz", vibecheckController.GetRandomProblem)
 	router.POST("/problem/answer", vibecheckController.AnswerProblem)
 	router.GET("/problem/hint/:tweetId", vibecheckController.GetHint)
+	router.GET("/daily", vibecheckController.GetDailyChallenge)
+	router.POST("/daily/answer", vibecheckController.AnswerDailyChallenge)
+	router.GET("/daily/results", vibecheckController.GetDailyResults)
+	router.GET("/labeling/next", vibecheckController.GetLabelingProblem)
+	router.POST("/rounds", vibecheckController.StartRound)
+	router.GET("/rounds/:id", vibecheckController.GetRound)
+	router.POST("/rounds/:id/answer", vibecheckController.AnswerRound)
+	router.POST("/rounds/:id/close", vibecheckController.CloseRound)
+
+	// GraphQL routes
+	router.GET("/graphql", vibecheckController.GraphQL)
+	router.POST("/graphql", vibecheckController.GraphQL)
+
+	// Event routes
+	router.GET("/events/stream", vibecheckController.StreamEvents)
+
+	// Room routes
+	router.POST("/rooms", vibecheckController.CreateRoom)
+	router.GET("/rooms/:code", vibecheckController.GetRoom)
+	router.POST("/rooms/:code/join", vibecheckController.JoinRoom)
+	router.POST("/rooms/:code/next", vibecheckController.NextRoomProblem)
+	router.POST("/rooms/:code/answer", vibecheckController.AnswerRoom)
+	router.GET("/rooms/:code/ws", vibecheckController.RoomSocket)
+
+	// Labeling routes
+	router.GET("/labeling/items/:id", vibecheckController.GetLabelingItem)
+	router.GET("/labeling/report", vibecheckController.GetLabelingReport)
+
+	// Moderation routes
+	moderation := router.Group("/moderation", vibecheckController.RequireAdmin)
+	moderation.GET("/queue", vibecheckController.GetModerationQueue)
+	moderation.POST("/:id/approve", vibecheckController.ApproveSubmission)
+	moderation.POST("/:id/reject", vibecheckController.RejectSubmission)
+
+	// Review routes
+	router.GET("/review/queue", vibecheckController.RequireAdmin, vibecheckController.GetReviewQueue)
+	router.POST("/review/scan", vibecheckController.RequireAdmin, vibecheckController.ScanDisagreements)
+	router.POST("/review/:id/confirm", vibecheckController.RequireAdmin, vibecheckController.ConfirmReviewItem)
+	router.POST("/review/:id/relabel", vibecheckController.RequireAdmin, vibecheckController.RelabelReviewItem)
+
+	// Hint routes
+	router.GET("/hints/generated", vibecheckController.RequireAdmin, vibecheckController.GetGeneratedHints)
+	router.POST("/hints/generate", vibecheckController.RequireAdmin, vibecheckController.GenerateMissingHints)
+	router.POST("/tweets/:id/hint/accept", vibecheckController.RequireAdmin, vibecheckController.AcceptHint)
+	router.PUT("/tweets/:id/hint", vibecheckController.RequireAdmin, vibecheckController.OverwriteHint)
+
+	// Admin routes
+	admin := router.Group("/admin", vibecheckController.RequireAdmin)
+	admin.GET("/duplicates", vibecheckController.GetDuplicateClusters)
+	admin.GET("/dataset/report", vibecheckController.GetDatasetReport)
+	admin.GET("/classifiers", vibecheckController.GetClassifiers)
+	admin.GET("/classifiers/compare", vibecheckController.CompareClassifiers)
+	admin.POST("/classifiers/:name/score", vibecheckController.ScorePredictions)
+	admin.POST("/webhooks", vibecheckController.RegisterWebhook)
+	admin.GET("/webhooks", vibecheckController.GetWebhooks)
+	admin.DELETE("/webhooks/:id", vibecheckController.DeleteWebhook)
+	admin.GET("/webhooks/:id/deliveries", vibecheckController.GetWebhookDeliveries)
+	admin.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", vibecheckController.RedeliverWebhook)
+
+	// Player routes
+	router.GET("/me/stats", vibecheckController.GetMyStats)
+	router.GET("/me/rounds", vibecheckController.GetMyRounds)
+	router.GET("/me/versus", vibecheckController.GetMyVersus)
+	router.GET("/leaderboard", vibecheckController.GetLeaderboard)
+	router.GET("/versus", vibecheckController.GetVersus)
 }
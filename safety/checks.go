@@ -0,0 +1,257 @@
+package safety
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// leetspeak maps look-alike characters to the letters they stand for
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '2': 'z', '3': 'e', '4': 'a', '5': 's', '6': 'g', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '|': 'l', '+': 't', '€': 'e',
+}
+
+// wordLike reports whether r is a letter or stands for one
+func wordLike(r rune) bool {
+	_, leet := leetspeak[r]
+	return leet || unicode.IsLetter(r)
+}
+
+// Normalize lowercases a text, undoes leetspeak, collapses letters repeated more than
+// twice and turns everything that is not a letter into a single space. "!" stands for
+// "i" only between letters, so a trailing one ends a word as punctuation.
+func Normalize(text string) string {
+	var b strings.Builder
+	var last rune
+	repeats := 0
+	space := true
+	runes := []rune(strings.ToLower(text))
+	for i, r := range runes {
+		if mapped, ok := leetspeak[r]; ok {
+			r = mapped
+		} else if r == '!' && i > 0 && i+1 < len(runes) && wordLike(runes[i-1]) && wordLike(runes[i+1]) {
+			r = 'i'
+		}
+		if !unicode.IsLetter(r) {
+			if !space {
+				b.WriteRune(' ')
+				space = true
+			}
+			last, repeats = 0, 0
+			continue
+		}
+		if r == last {
+			repeats++
+			if repeats >= 2 {
+				continue
+			}
+		} else {
+			last, repeats = r, 0
+		}
+		b.WriteRune(r)
+		space = false
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// joinSpelledOut merges runs of single letters, so "s l u r" is matched as "slur". A run
+// starting with "a" or "i" may start with that word, so it is also matched without it, and
+// short runs only without it, so "I am a s s" does not spell out a term.
+func joinSpelledOut(words []string) []string {
+	var joined []string
+	var run strings.Builder
+	flush := func() {
+		letters := run.String()
+		run.Reset()
+		if strings.HasPrefix(letters, "a") || strings.HasPrefix(letters, "i") {
+			if utf8.RuneCountInString(letters) >= 4 {
+				joined = append(joined, letters)
+			}
+			letters = letters[1:]
+		}
+		if utf8.RuneCountInString(letters) > 1 {
+			joined = append(joined, letters)
+		}
+	}
+	for _, word := range words {
+		if utf8.RuneCountInString(word) == 1 {
+			run.WriteString(word)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return joined
+}
+
+// squeeze collapses every run of a repeated letter, so stretched spellings match their term
+func squeeze(word string) string {
+	var b strings.Builder
+	var last rune
+	for _, r := range word {
+		if r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
+
+// Blocklist flags texts containing listed terms, after leetspeak normalization
+type Blocklist struct {
+	terms    map[string]string
+	phrases  []string
+	severity string
+}
+
+// NewBlocklist creates a blocklist check. Terms may be words or phrases and are
+// normalized like the text they are matched against.
+func NewBlocklist(terms []string, severity string) *Blocklist {
+	b := &Blocklist{terms: map[string]string{}, severity: severity}
+	for _, term := range terms {
+		normalized := Normalize(term)
+		if normalized == "" {
+			continue
+		}
+		if strings.Contains(normalized, " ") {
+			b.phrases = append(b.phrases, normalized)
+		} else {
+			b.terms[squeeze(normalized)] = normalized
+		}
+	}
+	return b
+}
+
+func (b *Blocklist) Name() string {
+	return "blocklist"
+}
+
+func (b *Blocklist) Check(text string) []Reason {
+	normalized := Normalize(text)
+	words := strings.Fields(normalized)
+
+	var found []string
+	seen := map[string]bool{}
+	match := func(term string) {
+		if !seen[term] {
+			seen[term] = true
+			found = append(found, term)
+		}
+	}
+	for _, word := range append(words, joinSpelledOut(words)...) {
+		if term, ok := b.terms[squeeze(word)]; ok {
+			match(term)
+		}
+	}
+	padded := " " + normalized + " "
+	for _, phrase := range b.phrases {
+		if strings.Contains(padded, " "+phrase+" ") {
+			match(phrase)
+		}
+	}
+
+	reasons := make([]Reason, 0, len(found))
+	for _, term := range found {
+		reasons = append(reasons, Reason{
+			Check:    b.Name(),
+			Code:     "blocked_term",
+			Severity: b.severity,
+			Message:  "Text contains a disallowed term",
+			Match:    term,
+		})
+	}
+	return reasons
+}
+
+var (
+	emailPattern = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+	// phonePattern finds digit groups joined by single separators, as phone numbers are written;
+	// isPhoneNumber then decides whether the groups look like one
+	phonePattern = regexp.MustCompile(`\+?(?:\(\d{1,4}\)|\d{1,4})(?:[ .\-]?(?:\(\d{1,4}\)|\d{1,4}))*`)
+	datePattern  = regexp.MustCompile(`^(?:\d{4}[.\-]\d{1,2}[.\-]\d{1,2}|\d{1,2}[.\-]\d{1,2}[.\-]\d{2,4})$`)
+	urlPattern   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s]+|\b[a-z0-9\-]+\.(?:com|net|org|io|co|me|ly|gg|info|biz)(?:/[^\s]*)?\b`)
+)
+
+const (
+	minPhoneDigits = 7
+	maxPhoneDigits = 15
+)
+
+// PII flags personal contact details: emails and phone numbers block, URLs need review
+type PII struct{}
+
+func (PII) Name() string {
+	return "pii"
+}
+
+func (p PII) Check(text string) []Reason {
+	var reasons []Reason
+	for _, match := range emailPattern.FindAllString(text, -1) {
+		reasons = append(reasons, Reason{Check: p.Name(), Code: "email", Severity: SeverityBlock, Message: "Text contains an email address", Match: match})
+	}
+	// Emails are removed first so their digits are not mistaken for phone numbers
+	withoutEmails := emailPattern.ReplaceAllString(text, " ")
+	for _, match := range phonePattern.FindAllString(withoutEmails, -1) {
+		if isPhoneNumber(match) {
+			reasons = append(reasons, Reason{Check: p.Name(), Code: "phone_number", Severity: SeverityBlock, Message: "Text contains a phone number", Match: match})
+		}
+	}
+	for _, match := range urlPattern.FindAllString(withoutEmails, -1) {
+		reasons = append(reasons, Reason{Check: p.Name(), Code: "url", Severity: SeverityReview, Message: "Text contains a link", Match: match})
+	}
+	return reasons
+}
+
+// isPhoneNumber reports whether digit groups are grouped like a phone number: international
+// with a country code, with an area code in parentheses or a leading trunk 0, or in the
+// 3-3-4 and 3-4 North American groupings. Dates, scores and other runs of numbers are not.
+func isPhoneNumber(match string) bool {
+	var groups []string
+	digits := 0
+	for _, group := range strings.FieldsFunc(match, func(r rune) bool { return !unicode.IsDigit(r) }) {
+		groups = append(groups, group)
+		digits += len(group)
+	}
+	if digits < minPhoneDigits || digits > maxPhoneDigits || datePattern.MatchString(match) {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(match, "+"):
+		return digits >= 8
+	case strings.Contains(match, "("):
+		return true
+	case strings.HasPrefix(match, "0"):
+		return len(groups) > 1 && digits >= 9
+	}
+	lengths := make([]int, len(groups))
+	for i, group := range groups {
+		lengths[i] = len(group)
+	}
+	return slices.Equal(lengths, []int{3, 3, 4}) || slices.Equal(lengths, []int{3, 4}) ||
+		(slices.Equal(lengths, []int{1, 3, 3, 4}) && groups[0] == "1")
+}
+
+// Length rejects texts that are empty, too short or too long, counted in characters
+type Length struct {
+	Min int
+	Max int
+}
+
+func (Length) Name() string {
+	return "length"
+}
+
+func (l Length) Check(text string) []Reason {
+	n := utf8.RuneCountInString(strings.TrimSpace(text))
+	switch {
+	case n < l.Min:
+		return []Reason{{Check: l.Name(), Code: "too_short", Severity: SeverityBlock, Message: fmt.Sprintf("Text must be at least %d characters", l.Min)}}
+	case l.Max > 0 && n > l.Max:
+		return []Reason{{Check: l.Name(), Code: "too_long", Severity: SeverityBlock, Message: fmt.Sprintf("Text must be at most %d characters", l.Max)}}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package safety
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// codes lists the codes of the reasons a check found, in order
+func codes(reasons []Reason) []string {
+	found := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		found = append(found, reason.Code)
+	}
+	return found
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Hello, World.", "hello world"},
+		{"wow!", "wow"},
+		{"you idiot!", "you idiot"},
+		{"sh!t", "shit"},
+		{"!!!", ""},
+		{"h3ll0 w0rld", "hello world"},
+		{"sooooo goooood", "soo good"},
+		{"  --  ", ""},
+		{"$h1t", "shit"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.text); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestJoinSpelledOut(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"s l u r", []string{"slur"}},
+		{"you are a s l u r", []string{"aslur", "slur"}},
+		{"I am a s s", []string{"ss"}},
+		{"a b", nil},
+		{"i d i o t", []string{"idiot", "diot"}},
+		{"x y z and p q", []string{"xyz", "pq"}},
+		{"no single letters here", nil},
+	}
+	for _, tt := range tests {
+		got := joinSpelledOut(strings.Fields(Normalize(tt.text)))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("joinSpelledOut(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBlocklist(t *testing.T) {
+	blocklist := NewBlocklist([]string{"slur", "ass", "bad phrase"}, SeverityBlock)
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"what a slur", []string{"slur"}},
+		{"what a $lur", []string{"slur"}},
+		{"what a sluuuuur", []string{"slur"}},
+		{"what a s l u r", []string{"slur"}},
+		{"that is a BAD phrase indeed", []string{"bad phrase"}},
+		{"I am a s s", nil},
+		{"a bad day for phrases", nil},
+		{"slurp your soup", nil},
+		{"you ass", []string{"ass"}},
+		{"what a slur!", []string{"slur"}},
+		{"what a slur!!!", []string{"slur"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, reason := range blocklist.Check(tt.text) {
+			got = append(got, reason.Match)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Blocklist.Check(%q) matched %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPII(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"mail me at jane.doe@example.com", []string{"email"}},
+		{"call +44 20 7946 0958 tonight", []string{"phone_number"}},
+		{"call +1 (555) 123-4567", []string{"phone_number"}},
+		{"call (555) 123-4567", []string{"phone_number"}},
+		{"call 555-123-4567", []string{"phone_number"}},
+		{"call 555.123.4567", []string{"phone_number"}},
+		{"call 1-555-123-4567", []string{"phone_number"}},
+		{"call 020 7946 0958", []string{"phone_number"}},
+		{"call 06 12 34 56 78", []string{"phone_number"}},
+		{"ring 555-1234", []string{"phone_number"}},
+		{"see www.example.com", []string{"url"}},
+		{"Match on 2024-01-15 was great", nil},
+		{"Match on 15.01.2024 was great", nil},
+		{"scores 100 - 200 - 300", nil},
+		{"scores 100-200-300", nil},
+		{"we sold 1.234.567 copies", nil},
+		{"the 2023 season had 38 games", nil},
+		{"version 2.0.1 is out", nil},
+		{"jane2024@example.com", []string{"email"}},
+	}
+	for _, tt := range tests {
+		got := codes(PII{}.Check(tt.text))
+		if len(got) == 0 {
+			got = nil
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("PII.Check(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLength(t *testing.T) {
+	length := Length{Min: 3, Max: 10}
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"  ", []string{"too_short"}},
+		{"hi", []string{"too_short"}},
+		{"hey", nil},
+		{"héllo wörld", []string{"too_long"}},
+		{"héllo wörl", nil},
+	}
+	for _, tt := range tests {
+		got := codes(length.Check(tt.text))
+		if len(got) == 0 {
+			got = nil
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Length.Check(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	pipeline := NewPipeline(NewBlocklist([]string{"slur"}, SeverityBlock), NewBlocklist([]string{"meh"}, SeverityReview), PII{})
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Match on 2024-01-15 was great", ActionAllow},
+		{"meh, see www.example.com", ActionReview},
+		{"meh, call 555-123-4567", ActionReject},
+		{"what a slur", ActionReject},
+	}
+	for _, tt := range tests {
+		if got := pipeline.Run(tt.text).Action; got != tt.want {
+			t.Errorf("Run(%q).Action = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
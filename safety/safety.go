@@ -0,0 +1,114 @@
+// Package safety screens user-supplied text before it is stored.
+//
+// A Pipeline runs a list of Checks over a text and folds their findings into a
+// Verdict: content is allowed, sent to moderation for review, or rejected.
+package safety
+
+import "strings"
+
+const (
+	// SeverityReview marks borderline content that a moderator should look at
+	SeverityReview = "review"
+	// SeverityBlock marks content that must not be stored
+	SeverityBlock = "block"
+)
+
+const (
+	ActionAllow  = "allow"
+	ActionReview = "review"
+	ActionReject = "reject"
+)
+
+// Reason explains why a check flagged a text
+type Reason struct {
+	Field    string `json:"field,omitempty"`
+	Check    string `json:"check"`
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Match    string `json:"match,omitempty"`
+}
+
+// Check inspects a text and reports what it finds objectionable
+type Check interface {
+	Name() string
+	Check(text string) []Reason
+}
+
+// Verdict is the outcome of running a pipeline over a text
+type Verdict struct {
+	Action  string   `json:"action"`
+	Reasons []Reason `json:"reasons"`
+}
+
+// Rejection is the error returned for content that must not be stored
+type Rejection struct {
+	Reasons []Reason
+}
+
+func (r *Rejection) Error() string {
+	codes := make([]string, 0, len(r.Reasons))
+	for _, reason := range r.Reasons {
+		codes = append(codes, reason.Code)
+	}
+	return "content rejected: " + strings.Join(codes, ", ")
+}
+
+// Pipeline runs checks in order and combines their reasons
+type Pipeline struct {
+	checks []Check
+}
+
+// NewPipeline creates a pipeline running the given checks
+func NewPipeline(checks ...Check) *Pipeline {
+	return &Pipeline{checks: checks}
+}
+
+// Add appends a check to the pipeline
+func (p *Pipeline) Add(check Check) {
+	p.checks = append(p.checks, check)
+}
+
+// Allow returns a verdict with no reasons
+func Allow() Verdict {
+	return Verdict{Action: ActionAllow, Reasons: []Reason{}}
+}
+
+// Run checks a text. Any blocking reason rejects it, any review reason sends it to moderation.
+func (p *Pipeline) Run(text string) Verdict {
+	verdict := Allow()
+	for _, check := range p.checks {
+		for _, reason := range check.Check(text) {
+			verdict.add(reason)
+		}
+	}
+	return verdict
+}
+
+func (v *Verdict) add(reason Reason) {
+	v.Reasons = append(v.Reasons, reason)
+	switch reason.Severity {
+	case SeverityBlock:
+		v.Action = ActionReject
+	case SeverityReview:
+		if v.Action == ActionAllow {
+			v.Action = ActionReview
+		}
+	}
+}
+
+// Merge adds the reasons of another verdict, tagged with the field they were found in
+func (v *Verdict) Merge(field string, other Verdict) {
+	for _, reason := range other.Reasons {
+		reason.Field = field
+		v.add(reason)
+	}
+}
+
+// Err returns a Rejection for rejected verdicts and nil otherwise
+func (v Verdict) Err() error {
+	if v.Action != ActionReject {
+		return nil
+	}
+	return &Rejection{Reasons: v.Reasons}
+}
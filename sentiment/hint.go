@@ -0,0 +1,89 @@
+package sentiment
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// sarcasmCues are phrases that often mean the opposite of what they literally say
+var sarcasmCues = []string{
+	"yeah right", "oh great", "just great", "oh wonderful", "just what i needed", "thanks a lot",
+	"thanks for nothing", "so much fun", "love it when", "love how", "gotta love", "i just love",
+	"as if", "big surprise", "what a surprise", "wow just wow", "#sarcasm", "/s",
+}
+
+const (
+	// maxHintWords is how many sentiment-bearing words a hint points out
+	maxHintWords = 2
+	// maxHintCues is how many cues a hint mentions, the first found being the most telling
+	maxHintCues = 3
+)
+
+// sarcasmCue returns the first sarcasm cue found at the start of a word in a text
+func sarcasmCue(text string) string {
+	lower := " " + strings.ToLower(strings.ReplaceAll(text, "’", "'"))
+	for _, cue := range sarcasmCues {
+		if strings.Contains(lower, " "+cue) {
+			return cue
+		}
+	}
+	return ""
+}
+
+// Hint derives a hint from the cues in a text that carry its sentiment: sarcasm cues,
+// negations, contrast, emoji and the most sentiment-bearing words. Hints point at cues
+// without naming a label.
+func Hint(text string) string {
+	analysis := Analyze(text)
+	var hints []string
+
+	var words, emojis []Word
+	wordTone, emojiTone := 0.0, 0.0
+	for _, word := range analysis.Words {
+		if word.Emoji {
+			emojis = append(emojis, word)
+			emojiTone += word.Valence
+		} else {
+			words = append(words, word)
+			wordTone += word.Valence
+		}
+	}
+
+	if cue := sarcasmCue(text); cue != "" {
+		hints = append(hints, fmt.Sprintf("Is %q meant literally?", cue))
+	} else if wordTone*emojiTone < 0 {
+		hints = append(hints, "The words and the emoji don't quite agree.")
+	}
+	for _, word := range words {
+		if word.Negated {
+			hints = append(hints, fmt.Sprintf("Notice the negation before %q.", word.Text))
+			break
+		}
+	}
+	if analysis.Contrast {
+		hints = append(hints, `What comes after "but" usually matters most.`)
+	}
+	if len(emojis) > 0 {
+		texts := make([]string, 0, len(emojis))
+		for _, e := range emojis {
+			texts = append(texts, e.Text)
+		}
+		hints = append(hints, fmt.Sprintf("Look at the emoji: %s.", strings.Join(texts, " ")))
+	}
+	if len(words) > 0 {
+		sort.SliceStable(words, func(i, j int) bool {
+			return math.Abs(words[i].Valence) > math.Abs(words[j].Valence)
+		})
+		quoted := make([]string, 0, maxHintWords)
+		for _, word := range words[:min(len(words), maxHintWords)] {
+			quoted = append(quoted, fmt.Sprintf("%q", word.Text))
+		}
+		hints = append(hints, "Focus on "+strings.Join(quoted, " and ")+".")
+	}
+	if len(hints) == 0 {
+		return "No single word gives it away, think about the overall tone."
+	}
+	return strings.Join(hints[:min(len(hints), maxHintCues)], " ")
+}
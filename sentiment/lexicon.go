@@ -0,0 +1,95 @@
+package sentiment
+
+// lexicon holds the valence of sentiment-bearing words, from -4 (most negative) to 4 (most positive)
+var lexicon = map[string]float64{
+	// Positive
+	"good": 1.9, "great": 3.1, "excellent": 2.7, "amazing": 2.8, "awesome": 3.1, "fantastic": 2.6,
+	"wonderful": 2.7, "brilliant": 2.8, "superb": 2.9, "outstanding": 3.0, "perfect": 2.7, "best": 3.2,
+	"better": 1.9, "nice": 1.8, "fine": 0.8, "ok": 0.9, "okay": 0.9, "cool": 1.3, "love": 3.2,
+	"loved": 2.9, "loves": 2.7, "loving": 2.9, "lovely": 2.8, "like": 1.5, "liked": 1.8, "likes": 1.8,
+	"enjoy": 2.2, "enjoyed": 2.3, "enjoying": 2.4, "happy": 2.7, "happier": 2.4, "glad": 2.0,
+	"pleased": 1.9, "delighted": 2.9, "excited": 1.8, "exciting": 2.2, "thrilled": 2.8, "fun": 2.3,
+	"funny": 1.9, "beautiful": 2.9, "cute": 2.0, "sweet": 2.0, "win": 2.8, "won": 2.7,
+	"winning": 2.4, "success": 2.7, "successful": 2.8, "thanks": 1.9, "thank": 1.5, "thankful": 2.7,
+	"grateful": 2.0, "appreciate": 1.7, "appreciated": 2.3, "recommend": 1.5, "recommended": 1.9,
+	"helpful": 1.8, "useful": 1.9, "easy": 1.9, "fast": 1.1, "smooth": 1.2, "reliable": 1.6,
+	"impressive": 2.3, "impressed": 2.1, "incredible": 2.4, "favorite": 2.0, "favourite": 2.0,
+	"yay": 2.4, "wow": 2.8, "lol": 1.8, "haha": 2.0, "hahaha": 2.6, "lmao": 2.0, "hope": 1.9,
+	"hopeful": 1.6, "proud": 2.1, "fresh": 1.3, "calm": 1.3, "safe": 1.9, "comfortable": 2.0,
+	"worth": 0.9, "positive": 2.6, "glorious": 2.6, "joy": 2.8, "joyful": 2.9, "peace": 2.5,
+	"peaceful": 2.2, "blessed": 2.9, "congrats": 2.4, "congratulations": 2.9, "celebrate": 2.7,
+	"smile": 1.5, "smiling": 2.0, "laugh": 2.6, "laughing": 2.2, "friend": 2.2, "friends": 2.1,
+	"fair": 1.3, "clean": 1.7, "solid": 1.3, "stunning": 3.2, "gorgeous": 3.0,
+	"epic": 2.5, "legendary": 2.5, "masterpiece": 3.1, "flawless": 2.3, "satisfied": 1.8,
+	"satisfying": 2.0, "relieved": 1.6, "relief": 1.6, "wins": 2.7, "boost": 1.7, "improved": 2.1,
+	"improvement": 2.0, "support": 1.7, "supportive": 1.9, "trust": 2.3, "welcome": 2.0,
+	"fabulous": 2.4, "terrific": 2.1, "yes": 1.7, "heaven": 2.8, "paradise": 3.2, "agree": 1.5,
+
+	// Negative
+	"bad": -2.5, "worse": -2.1, "worst": -3.1, "terrible": -2.1, "horrible": -2.5, "awful": -2.0,
+	"poor": -2.1, "sad": -2.1, "sadly": -1.8, "unhappy": -1.8, "hate": -2.7, "hated": -3.2,
+	"hates": -1.9, "hating": -2.3, "dislike": -1.6, "angry": -2.3, "mad": -2.2, "annoying": -1.7,
+	"annoyed": -1.6, "upset": -1.6, "disappointed": -1.9, "disappointing": -2.2, "disappointment": -2.3,
+	"fail": -2.5, "failed": -2.3, "fails": -2.2, "failure": -2.3, "broken": -1.9, "break": -1.2,
+	"broke": -1.8, "bug": -1.0, "buggy": -1.7, "crash": -1.7, "crashed": -1.8, "crashes": -1.5,
+	"slow": -1.1, "lag": -1.2, "laggy": -1.5, "useless": -1.8, "waste": -1.8, "wasted": -2.2,
+	"boring": -1.3, "bored": -1.1, "stupid": -2.4, "dumb": -2.3, "ugly": -2.3, "gross": -2.1,
+	"disgusting": -2.4, "nasty": -2.6, "sick": -2.3, "hurt": -2.4, "hurts": -2.1, "pain": -2.3,
+	"painful": -1.9, "cry": -2.1, "crying": -2.1, "tears": -0.9, "scared": -1.9, "afraid": -2.0,
+	"fear": -2.2, "worried": -1.2, "worry": -1.9, "stress": -1.8, "stressed": -1.4, "stressful": -2.1,
+	"tired": -1.9, "exhausted": -1.5, "lonely": -1.5, "alone": -1.0, "miss": -0.6, "missed": -1.2,
+	"lost": -1.3, "lose": -1.7, "losing": -1.6, "loss": -1.3, "problem": -1.7, "problems": -1.7,
+	"issue": -0.6, "issues": -0.7, "wrong": -2.1, "mess": -1.5, "messy": -1.5, "sucks": -1.5,
+	"suck": -1.9, "sucked": -2.0, "crap": -1.6, "damn": -1.7, "wtf": -2.8, "ugh": -1.8, "meh": -0.3,
+	"ridiculous": -1.5, "pathetic": -2.3, "rude": -2.0, "mean": -0.5, "evil": -3.4,
+	"cruel": -2.8, "kill": -3.7, "killed": -3.5, "dead": -3.3, "die": -2.9, "death": -2.9,
+	"disaster": -3.1, "tragic": -3.4, "tragedy": -3.4, "sorry": -0.3, "regret": -1.8, "shame": -2.1,
+	"awkward": -0.6, "confused": -1.3, "confusing": -0.9, "frustrated": -2.4, "frustrating": -1.9,
+	"furious": -2.7, "outraged": -2.3, "horrific": -3.4, "hopeless": -2.0, "depressed": -2.3,
+	"depressing": -1.6, "miserable": -2.2, "toxic": -2.2, "scam": -2.2, "fake": -2.1, "liar": -2.5,
+	"lies": -1.8, "overpriced": -1.7, "expensive": -0.9, "cancelled": -1.0, "canceled": -1.0,
+	"delay": -1.3, "delayed": -1.0, "late": -0.5, "hell": -3.6, "nightmare": -2.7,
+	"unfair": -2.1, "weak": -1.9, "worthless": -1.9, "garbage": -2.4, "trash": -1.5, "fml": -2.7,
+	"smh": -1.3, "yikes": -1.3, "cringe": -1.6, "fuming": -2.7, "ruined": -2.4, "ruin": -2.1,
+
+	// Emoticons
+	":)": 2.0, ":-)": 2.0, ":d": 2.3, ":-d": 2.3, ";)": 1.6, ";-)": 1.6, ":p": 1.3, "xd": 2.0,
+	"<3": 1.9, ":(": -1.9, ":-(": -1.9, ":'(": -2.3, ":/": -1.4, ":-/": -1.4, ">:(": -2.6, "</3": -2.3,
+}
+
+// emoji holds the valence of common emoji
+var emoji = map[rune]float64{
+	'😀': 2.5, '😃': 2.5, '😄': 2.6, '😁': 2.4, '😆': 2.3, '😂': 2.3, '🤣': 2.3, '😊': 2.4, '🙂': 1.4,
+	'😍': 3.0, '🥰': 3.0, '😘': 2.5, '😎': 1.9, '🤩': 2.9, '🥳': 2.9, '👍': 1.9, '👏': 2.0, '🙌': 2.1,
+	'🎉': 2.5, '❤': 2.6, '💕': 2.6, '💖': 2.7, '💯': 2.2, '🔥': 1.5, '✨': 1.5, '🙏': 1.3, '😇': 2.3,
+	'😢': -2.2, '😭': -2.4, '😞': -2.0, '😔': -1.8, '😟': -1.7, '😕': -1.3, '🙁': -1.6, '☹': -1.9,
+	'😠': -2.5, '😡': -2.9, '🤬': -3.2, '😤': -1.8, '😩': -2.0, '😫': -2.1, '😒': -1.6, '🙄': -1.3,
+	'😱': -1.8, '😨': -1.9, '😰': -1.9, '🤮': -2.6, '🤢': -2.1, '💔': -2.5, '👎': -2.0, '💩': -1.8,
+	'😐': -0.2, '😑': -0.4,
+}
+
+// boosters scale the valence of the word they precede, up for intensifiers and down for dampeners
+var boosters = map[string]float64{
+	"absolutely": boostIncrease, "amazingly": boostIncrease, "completely": boostIncrease,
+	"deeply": boostIncrease, "especially": boostIncrease, "extremely": boostIncrease,
+	"fully": boostIncrease, "highly": boostIncrease, "hugely": boostIncrease, "incredibly": boostIncrease,
+	"insanely": boostIncrease, "most": boostIncrease, "particularly": boostIncrease, "purely": boostIncrease,
+	"really": boostIncrease, "so": boostIncrease, "soo": boostIncrease, "sooo": boostIncrease,
+	"super": boostIncrease, "totally": boostIncrease, "truly": boostIncrease, "utterly": boostIncrease,
+	"very": boostIncrease, "way": boostIncrease, "too": boostIncrease, "freaking": boostIncrease,
+	"almost": boostDecrease, "barely": boostDecrease, "hardly": boostDecrease, "kinda": boostDecrease,
+	"less": boostDecrease, "little": boostDecrease, "marginally": boostDecrease,
+	"occasionally": boostDecrease, "partly": boostDecrease, "scarcely": boostDecrease,
+	"slightly": boostDecrease, "somewhat": boostDecrease, "sorta": boostDecrease, "pretty": boostDecrease, "bit": boostDecrease,
+}
+
+// negations flip the valence of the words following them
+var negations = map[string]bool{
+	"not": true, "no": true, "never": true, "none": true, "nobody": true, "nothing": true,
+	"neither": true, "nor": true, "nowhere": true, "without": true, "cannot": true, "cant": true,
+	"can't": true, "dont": true, "don't": true, "doesnt": true, "doesn't": true, "didnt": true,
+	"didn't": true, "isnt": true, "isn't": true, "arent": true, "aren't": true, "wasnt": true,
+	"wasn't": true, "werent": true, "weren't": true, "wont": true, "won't": true, "wouldnt": true,
+	"wouldn't": true, "shouldnt": true, "shouldn't": true, "couldnt": true, "couldn't": true,
+	"aint": true, "ain't": true, "hasnt": true, "hasn't": true, "havent": true, "haven't": true,
+}
@@ -0,0 +1,265 @@
+// Package sentiment classifies the sentiment of short texts with a lexicon and rules,
+// in the style of VADER: each word has a valence that intensifiers, negations,
+// capitalization, contrast and punctuation adjust, and the sum is normalized into a
+// compound score between -1 and 1.
+package sentiment
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Model and Version identify the classifier in stored predictions
+	Model   = "lexicon"
+	Version = "1"
+)
+
+const (
+	Positive = "positive"
+	Negative = "negative"
+	Neutral  = "neutral"
+)
+
+const (
+	boostIncrease  = 0.293
+	boostDecrease  = -0.293
+	capsIncrease   = 0.733
+	negationScalar = -0.74
+	// negationWindow and boostWindow are how many preceding words can negate or boost a word
+	negationWindow = 3
+	boostWindow    = 3
+	// beforeContrast and afterContrast weigh words around "but", which usually carries the point
+	beforeContrast  = 0.5
+	afterContrast   = 1.5
+	exclamationStep = 0.292
+	maxExclamations = 4
+	questionStep    = 0.18
+	maxQuestionGain = 0.96
+	// alpha normalizes the valence sum into the compound score
+	alpha = 15
+	// threshold is the compound score beyond which a text is no longer neutral
+	threshold = 0.05
+)
+
+// Prediction is the label and compound score the classifier assigns to a text
+type Prediction struct {
+	Label    string  `json:"label"`
+	Compound float64 `json:"compound"`
+}
+
+// Word is a sentiment-bearing word of a text and the valence it contributed
+type Word struct {
+	Text    string  `json:"text"`
+	Valence float64 `json:"valence"`
+	Negated bool    `json:"negated,omitempty"`
+	Boosted bool    `json:"boosted,omitempty"`
+	Emoji   bool    `json:"emoji,omitempty"`
+}
+
+// Analysis explains a prediction: which words carried sentiment and which cues adjusted it
+type Analysis struct {
+	Prediction
+	Words        []Word   `json:"words"`
+	Negations    []string `json:"negations"`
+	Contrast     bool     `json:"contrast"`
+	Exclamations int      `json:"exclamations"`
+	Questions    int      `json:"questions"`
+}
+
+// token is a word, emoticon or emoji of a text
+type token struct {
+	text  string
+	lower string
+	emoji bool
+}
+
+// tokenize splits a text into words, emoticons and emoji, dropping surrounding punctuation
+func tokenize(text string) []token {
+	text = strings.ReplaceAll(text, "’", "'")
+	var tokens []token
+	for _, field := range strings.Fields(text) {
+		if _, ok := lexicon[strings.ToLower(field)]; ok && !isWord(field) {
+			tokens = append(tokens, token{text: field, lower: strings.ToLower(field)})
+			continue
+		}
+
+		var word strings.Builder
+		flush := func() {
+			w := strings.Trim(word.String(), "'")
+			if w != "" {
+				tokens = append(tokens, token{text: w, lower: strings.ToLower(w)})
+			}
+			word.Reset()
+		}
+		for _, r := range field {
+			switch {
+			case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'':
+				word.WriteRune(r)
+			default:
+				flush()
+				if _, ok := emoji[r]; ok {
+					tokens = append(tokens, token{text: string(r), lower: string(r), emoji: true})
+				}
+			}
+		}
+		flush()
+	}
+	return tokens
+}
+
+func isWord(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && r != '\'' {
+			return false
+		}
+	}
+	return true
+}
+
+// shouting reports whether a word is written in capitals, which only counts as emphasis
+// when the rest of the text is not
+func shouting(t token, mixedCase bool) bool {
+	if !mixedCase || t.emoji || len([]rune(t.text)) < 2 {
+		return false
+	}
+	return strings.ToUpper(t.text) == t.text && strings.ToLower(t.text) != t.text
+}
+
+func sign(v float64) float64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
+
+// Analyze scores a text and reports the words and cues behind the score
+func Analyze(text string) Analysis {
+	tokens := tokenize(text)
+	analysis := Analysis{
+		Words:        []Word{},
+		Negations:    []string{},
+		Exclamations: strings.Count(text, "!"),
+		Questions:    strings.Count(text, "?"),
+	}
+
+	// Emphasis from capitals only counts when some words are lowercase
+	upper, lower := false, false
+	for _, t := range tokens {
+		if t.emoji {
+			continue
+		}
+		if strings.ToUpper(t.text) == t.text {
+			upper = true
+		} else {
+			lower = true
+		}
+	}
+	mixedCase := upper && lower
+
+	contrastAt := -1
+	for i, t := range tokens {
+		if negations[t.lower] {
+			analysis.Negations = append(analysis.Negations, t.text)
+		}
+		if t.lower == "but" && contrastAt < 0 {
+			contrastAt = i
+		}
+	}
+	analysis.Contrast = contrastAt >= 0
+
+	valences := make([]float64, len(tokens))
+	for i, t := range tokens {
+		var valence float64
+		var ok bool
+		if t.emoji {
+			valence, ok = emoji[[]rune(t.text)[0]]
+		} else {
+			valence, ok = lexicon[t.lower]
+		}
+		if !ok || valence == 0 {
+			continue
+		}
+		word := Word{Text: t.text, Emoji: t.emoji}
+
+		if shouting(t, mixedCase) {
+			valence += sign(valence) * capsIncrease
+		}
+		for d := 1; d <= boostWindow && i-d >= 0; d++ {
+			prev := tokens[i-d]
+			boost, ok := boosters[prev.lower]
+			if !ok {
+				continue
+			}
+			// Boosts fade with distance
+			boost *= 1 - 0.05*float64(d-1)
+			if shouting(prev, mixedCase) {
+				boost += sign(boost) * capsIncrease
+			}
+			valence += sign(valence) * boost
+			word.Boosted = true
+		}
+		for d := 1; d <= negationWindow && i-d >= 0; d++ {
+			if negations[tokens[i-d].lower] {
+				valence *= negationScalar
+				word.Negated = true
+				break
+			}
+		}
+		if contrastAt >= 0 {
+			if i < contrastAt {
+				valence *= beforeContrast
+			} else if i > contrastAt {
+				valence *= afterContrast
+			}
+		}
+
+		valences[i] = valence
+		word.Valence = math.Round(valence*1000) / 1000
+		analysis.Words = append(analysis.Words, word)
+	}
+
+	sum := 0.0
+	for _, v := range valences {
+		sum += v
+	}
+	if sum != 0 {
+		sum += sign(sum) * punctuationEmphasis(analysis.Exclamations, analysis.Questions)
+	}
+
+	analysis.Compound = math.Round(normalize(sum)*10000) / 10000
+	analysis.Label = label(analysis.Compound)
+	return analysis
+}
+
+// punctuationEmphasis is how much exclamation and question marks strengthen a text
+func punctuationEmphasis(exclamations, questions int) float64 {
+	emphasis := float64(min(exclamations, maxExclamations)) * exclamationStep
+	if questions > 1 {
+		emphasis += math.Min(float64(questions)*questionStep, maxQuestionGain)
+	}
+	return emphasis
+}
+
+// normalize maps a valence sum into the compound score, between -1 and 1
+func normalize(sum float64) float64 {
+	score := sum / math.Sqrt(sum*sum+alpha)
+	return math.Max(-1, math.Min(1, score))
+}
+
+func label(compound float64) string {
+	switch {
+	case compound >= threshold:
+		return Positive
+	case compound <= -threshold:
+		return Negative
+	default:
+		return Neutral
+	}
+}
+
+// Classify returns the label and compound score of a text
+func Classify(text string) Prediction {
+	return Analyze(text).Prediction
+}
@@ -0,0 +1,107 @@
+package sentiment
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []Word
+	}{
+		{"good", []Word{{Text: "good", Valence: 1.9}}},
+		{"nothing to see here", []Word{}},
+
+		// Negations flip and dampen words up to three words later
+		{"not good", []Word{{Text: "good", Valence: -1.406, Negated: true}}},
+		{"not at all good", []Word{{Text: "good", Valence: -1.406, Negated: true}}},
+		{"not that it was good", []Word{{Text: "good", Valence: 1.9}}},
+		{"isn't bad", []Word{{Text: "bad", Valence: 1.85, Negated: true}}},
+		{"not very good", []Word{{Text: "good", Valence: -1.623, Negated: true, Boosted: true}}},
+
+		// Boosters strengthen or weaken a word, fading with distance
+		{"very good", []Word{{Text: "good", Valence: 2.193, Boosted: true}}},
+		{"very bad", []Word{{Text: "bad", Valence: -2.793, Boosted: true}}},
+		{"slightly good", []Word{{Text: "good", Valence: 1.607, Boosted: true}}},
+		{"very very good", []Word{{Text: "good", Valence: 2.471, Boosted: true}}},
+
+		// Capitals are emphasis only when the rest of the text is lowercase
+		{"this is GOOD", []Word{{Text: "GOOD", Valence: 2.633}}},
+		{"THIS IS GOOD", []Word{{Text: "GOOD", Valence: 1.9}}},
+		{"VERY good", []Word{{Text: "good", Valence: 2.926, Boosted: true}}},
+
+		// Emoji and emoticons carry valence of their own
+		{"love it😍", []Word{{Text: "love", Valence: 3.2}, {Text: "😍", Valence: 3, Emoji: true}}},
+		{"fine :(", []Word{{Text: "fine", Valence: 0.8}, {Text: ":(", Valence: -1.9}}},
+
+		// What comes after "but" outweighs what comes before it
+		{"good but bad", []Word{{Text: "good", Valence: 0.95}, {Text: "bad", Valence: -3.75}}},
+	}
+	for _, tt := range tests {
+		if got := Analyze(tt.text).Words; !slices.Equal(got, tt.want) {
+			t.Errorf("Analyze(%q).Words = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"I love this", Positive},
+		{"I don't love this", Negative},
+		{"the bus leaves at noon", Neutral},
+		{"😡", Negative},
+		{"it crashed again", Negative},
+		{"the first one was bad but this one is amazing", Positive},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.text).Label; got != tt.want {
+			t.Errorf("Classify(%q).Label = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPunctuationEmphasis(t *testing.T) {
+	plain := Classify("good").Compound
+	excited := Classify("good!!").Compound
+	capped := Classify("good!!!!!!!!").Compound
+	if excited <= plain {
+		t.Errorf("Classify(%q) = %v, want more than %v", "good!!", excited, plain)
+	}
+	if capped != Classify("good!!!!").Compound {
+		t.Errorf("Classify(%q) = %v, want exclamations capped at %d", "good!!!!!!!!", capped, maxExclamations)
+	}
+	if got := Classify("hmm!!!").Compound; got != 0 {
+		t.Errorf("Classify(%q) = %v, want 0 without sentiment words", "hmm!!!", got)
+	}
+}
+
+func TestHint(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"oh great, another delay", []string{`Is "oh great" meant literally?`}},
+		{"I'm fine 😭", []string{"The words and the emoji don't quite agree.", "Look at the emoji: 😭."}},
+		{"this is not good", []string{`Notice the negation before "good".`, `Focus on "good".`}},
+		{"it looked nice but it was awful", []string{`What comes after "but" usually matters most.`, `Focus on "awful" and "nice".`}},
+		{"the bus leaves at noon", []string{"No single word gives it away, think about the overall tone."}},
+	}
+	for _, tt := range tests {
+		got := Hint(tt.text)
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("Hint(%q) = %q, want it to contain %q", tt.text, got, want)
+			}
+		}
+		for _, label := range []string{Positive, Negative, Neutral} {
+			if strings.Contains(strings.ToLower(got), label) {
+				t.Errorf("Hint(%q) = %q, names the label %q", tt.text, got, label)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"vibecheck/classifier"
+	"vibecheck/config"
+	"vibecheck/models"
+	"vibecheck/sentiment"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownClassifier = errors.New("unknown classifier")
+
+// scoreChunk is how many unscored tweets are classified and stored at a time, so a
+// failure part way through keeps what was already scored
+const scoreChunk = 500
+
+// newClassifiers registers the built-in classifier and, when configured, the external one
+func newClassifiers(cfg config.Config) map[string]classifier.Classifier {
+	classifiers := map[string]classifier.Classifier{sentiment.Model: classifier.Lexicon{}}
+	if cfg.Classifier.URL != "" {
+		external := classifier.NewHTTP(cfg.Classifier.Name, cfg.Classifier.Version, cfg.Classifier.URL, cfg.Classifier.Timeout, cfg.Classifier.BatchSize)
+		classifiers[external.Name()] = external
+	}
+	return classifiers
+}
+
+// GetClassifiers lists the registered classifiers
+func (s *VibecheckService) GetClassifiers() []models.ClassifierInfo {
+	infos := []models.ClassifierInfo{}
+	for _, clf := range s.classifiers {
+		_, external := clf.(*classifier.HTTP)
+		infos = append(infos, models.ClassifierInfo{Name: clf.Name(), Version: clf.Version(), BatchSize: clf.BatchSize(), External: external})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// ScorePredictions classifies every tweet the named classifier's current version has not
+// scored yet, and stores the predictions
+func (s *VibecheckService) ScorePredictions(name string) (int, error) {
+	clf, ok := s.classifiers[name]
+	if !ok {
+		return 0, ErrUnknownClassifier
+	}
+
+	query := `SELECT t.id, t.text FROM tweets t
+		WHERE NOT EXISTS (SELECT 1 FROM predictions p WHERE p.tweet_id = t.id AND p.model = $1 AND p.version = $2)
+		ORDER BY t.id`
+	rows, err := s.db.Query(query, clf.Name(), clf.Version())
+	if err != nil {
+		return 0, err
+	}
+	var tweets []models.Tweet
+	for rows.Next() {
+		var tweet models.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.Text); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		tweets = append(tweets, tweet)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	ctx := context.Background()
+	for start := 0; start < len(tweets); start += scoreChunk {
+		end := min(start+scoreChunk, len(tweets))
+		if _, err := s.scoreTweets(ctx, clf, tweets[start:end]); err != nil {
+			return start, err
+		}
+	}
+	return len(tweets), nil
+}
+
+// GetTweetPredictions retrieves the stored predictions of every classifier for a tweet
+func (s *VibecheckService) GetTweetPredictions(tweetID string) ([]models.Prediction, error) {
+	if _, err := uuid.Parse(tweetID); err != nil {
+		return nil, ErrTweetNotFound
+	}
+	tweet, err := s.GetTweet(tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if tweet == nil {
+		return nil, ErrTweetNotFound
+	}
+
+	rows, err := s.db.Query("SELECT model, version, label, score FROM predictions WHERE tweet_id = $1 ORDER BY model, version", tweetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	predictions := []models.Prediction{}
+	for rows.Next() {
+		prediction := models.Prediction{TweetID: tweet.ID, Answer: tweet.Answer}
+		if err := rows.Scan(&prediction.Model, &prediction.Version, &prediction.Label, &prediction.Score); err != nil {
+			return nil, err
+		}
+		if tweet.Answer != "" {
+			correct := prediction.Label == tweet.Answer
+			prediction.Correct = &correct
+		}
+		predictions = append(predictions, prediction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return predictions, nil
+}
+
+// CompareClassifiers measures every stored model version against the gold labels,
+// overall and per gold label
+func (s *VibecheckService) CompareClassifiers() ([]models.ModelComparison, error) {
+	query := `SELECT p.model, p.version, COALESCE(t.answer, ''), COUNT(*), COUNT(*) FILTER (WHERE p.label = t.answer)
+		FROM predictions p JOIN tweets t ON t.id = p.tweet_id
+		GROUP BY 1, 2, 3 ORDER BY 1, 2, 3`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comparisons := []models.ModelComparison{}
+	for rows.Next() {
+		var model, version, answer string
+		var scored, correct int
+		if err := rows.Scan(&model, &version, &answer, &scored, &correct); err != nil {
+			return nil, err
+		}
+		n := len(comparisons)
+		if n == 0 || comparisons[n-1].Model != model || comparisons[n-1].Version != version {
+			comparisons = append(comparisons, models.ModelComparison{Model: model, Version: version, LabelAccuracy: map[string]models.LabelStats{}})
+			n++
+		}
+		comparison := &comparisons[n-1]
+		comparison.Scored += scored
+		if answer == "" {
+			continue
+		}
+		comparison.Gold += scored
+		comparison.Correct += correct
+		comparison.LabelAccuracy[answer] = models.LabelStats{Attempts: scored, Correct: correct, Accuracy: ratio(correct, scored)}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range comparisons {
+		comparisons[i].Accuracy = ratio(comparisons[i].Correct, comparisons[i].Gold)
+	}
+	return comparisons, nil
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	"slices"
+	"time"
+	"vibecheck/models"
+
+	"github.com/lib/pq"
+)
+
+const dayLayout = "2006-01-02"
+
+var (
+	ErrAlreadyAnswered = errors.New("problem already answered")
+	ErrNotInChallenge  = errors.New("problem is not part of the daily challenge")
+	ErrInvalidDay      = errors.New("invalid day, expected YYYY-MM-DD")
+	ErrDailyNotFound   = errors.New("no daily challenge for this day")
+	ErrDailyProblem    = errors.New("problem is part of today's daily challenge")
+)
+
+// today returns the current UTC day
+func today() string {
+	return time.Now().UTC().Format(dayLayout)
+}
+
+// dailySeed derives the random seed for a day from the configured challenge seed
+func (s *VibecheckService) dailySeed(day string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(s.cfg.Daily.Seed + ":" + day))
+	return int64(h.Sum64())
+}
+
+// dailyChallengeIDs returns the tweet IDs of a day's challenge, choosing and storing them on first use
+func (s *VibecheckService) dailyChallengeIDs(day string) ([]string, error) {
+	var ids []string
+	query := "SELECT tweet_ids FROM daily_challenges WHERE day = $1"
+	err := s.db.QueryRow(query, day).Scan(pq.Array(&ids))
+	if err == nil {
+		return ids, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	// Candidates are drawn in a stable order so the seeded shuffle is reproducible,
+	// skipping problems used by recent challenges while enough remain
+	query = `SELECT id FROM tweets
+		WHERE status = 'published' AND answer IS NOT NULL AND id NOT IN (
+			SELECT unnest(tweet_ids) FROM daily_challenges WHERE day >= $1::date - $2::int
+		)
+		ORDER BY id`
+	candidates, err := s.queryIDs(query, day, s.cfg.Daily.RepeatDays)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) < s.cfg.Daily.Size {
+		candidates, err = s.queryIDs("SELECT id FROM tweets WHERE status = 'published' AND answer IS NOT NULL ORDER BY id")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("no tweets available")
+	}
+
+	rng := rand.New(rand.NewSource(s.dailySeed(day)))
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > s.cfg.Daily.Size {
+		candidates = candidates[:s.cfg.Daily.Size]
+	}
+
+	// Another replica may have chosen the day's problems first, in which case theirs win
+	query = "INSERT INTO daily_challenges (day, tweet_ids) VALUES ($1, $2) ON CONFLICT (day) DO NOTHING"
+	res, err := s.db.Exec(query, day, pq.Array(candidates))
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		s.publishEvent(EventDailyLive, models.DailyLiveEvent{Day: day, Problems: len(candidates)})
+	}
+	query = "SELECT tweet_ids FROM daily_challenges WHERE day = $1"
+	if err := s.db.QueryRow(query, day).Scan(pq.Array(&ids)); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// storedDailyIDs returns the tweet IDs of a day's challenge if they were chosen, without choosing them
+func (s *VibecheckService) storedDailyIDs(day string) ([]string, error) {
+	var ids []string
+	query := "SELECT tweet_ids FROM daily_challenges WHERE day = $1"
+	if err := s.db.QueryRow(query, day).Scan(pq.Array(&ids)); err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// isDailyProblem reports whether a tweet is part of today's challenge, which is only
+// answered through the challenge until the day is over
+func (s *VibecheckService) isDailyProblem(tweetID string) (bool, error) {
+	ids, err := s.storedDailyIDs(today())
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(ids, tweetID), nil
+}
+
+// queryIDs runs a query selecting a single ID column
+func (s *VibecheckService) queryIDs(query string, args ...interface{}) ([]string, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// getProblemsByIDs retrieves problems in the order of the given IDs
+func (s *VibecheckService) getProblemsByIDs(ids []string) ([]models.Problem, error) {
+	query := "SELECT id, text FROM tweets WHERE id = ANY($1::uuid[]) ORDER BY array_position($1::uuid[], id)"
+	rows, err := s.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	problems := []models.Problem{}
+	for rows.Next() {
+		var problem models.Problem
+		if err := rows.Scan(&problem.ID, &problem.Text); err != nil {
+			return nil, err
+		}
+		problems = append(problems, problem)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// GetDailyChallenge retrieves today's challenge problems without hints and answers
+func (s *VibecheckService) GetDailyChallenge() (*models.DailyChallenge, error) {
+	day := today()
+	ids, err := s.dailyChallengeIDs(day)
+	if err != nil {
+		return nil, err
+	}
+	problems, err := s.getProblemsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DailyChallenge{Day: day, Problems: problems}, nil
+}
+
+// SubmitDailyAnswer scores a player's single attempt at one of today's challenge problems
+func (s *VibecheckService) SubmitDailyAnswer(playerID string, attempt *models.AttemptSolution) (*models.AttemptResult, error) {
+	day := today()
+	ids, err := s.dailyChallengeIDs(day)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, id := range ids {
+		if id == attempt.ID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, ErrNotInChallenge
+	}
+
+	tweet, err := s.GetTweet(attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tweet == nil || tweet.Status != StatusPublished || tweet.Answer == "" {
+		return nil, ErrTweetNotFound
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The player's single attempt is claimed and scored together, so a failed scoring
+	// leaves it unclaimed
+	query := `INSERT INTO daily_challenge_attempts (day, player_id, tweet_id, guess, correct) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (day, player_id, tweet_id) DO NOTHING`
+	res, err := tx.Exec(query, day, playerID, attempt.ID, attempt.Guess, tweet.Answer == attempt.Guess)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrAlreadyAnswered
+	}
+	hintsUsed, elapsed := s.takeTracking(playerID, tweet.ID)
+	result, err := recordAttemptTx(tx, playerID, tweet, attempt.Guess, hintsUsed, elapsed)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.recordLeaderboardScore(playerID, tweet.Collection, result.Points)
+	s.announceDailyAnswers(day, attempt.ID)
+	if attempt.Bot {
+		if result.Bot, err = s.botResult(tweet, result.Correct); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetDailyResults retrieves how the community answered a day's challenge. Answers, and
+// how many guesses were correct, are held back until the day is over.
+func (s *VibecheckService) GetDailyResults(day string) (*models.DailyResults, error) {
+	if day == "" {
+		day = today()
+	}
+	if _, err := time.Parse(dayLayout, day); err != nil {
+		return nil, ErrInvalidDay
+	}
+
+	var ids []string
+	query := "SELECT tweet_ids FROM daily_challenges WHERE day = $1"
+	if err := s.db.QueryRow(query, day).Scan(pq.Array(&ids)); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDailyNotFound
+		}
+		return nil, err
+	}
+
+	results := &models.DailyResults{Day: day, Problems: []models.DailyProblemResult{}}
+	query = "SELECT COUNT(DISTINCT player_id) FROM daily_challenge_attempts WHERE day = $1"
+	if err := s.db.QueryRow(query, day).Scan(&results.Players); err != nil {
+		return nil, err
+	}
+
+	past := day < today()
+
+	query = `SELECT t.id, t.text, COALESCE(t.answer, ''), a.guess, COUNT(a.guess), COUNT(*) FILTER (WHERE a.correct)
+		FROM tweets t LEFT JOIN daily_challenge_attempts a ON a.tweet_id = t.id AND a.day = $2
+		WHERE t.id = ANY($1::uuid[])
+		GROUP BY t.id, t.text, t.answer, a.guess
+		ORDER BY array_position($1::uuid[], t.id)`
+	rows, err := s.db.Query(query, pq.Array(ids), day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, text, answer string
+		var guess sql.NullString
+		var count, correct int
+		if err := rows.Scan(&id, &text, &answer, &guess, &count, &correct); err != nil {
+			return nil, err
+		}
+		n := len(results.Problems)
+		if n == 0 || results.Problems[n-1].ID != id {
+			problem := models.DailyProblemResult{ID: id, Text: text, Guesses: map[string]int{}}
+			if past {
+				problem.Answer = answer
+			}
+			results.Problems = append(results.Problems, problem)
+			n++
+		}
+		problem := &results.Problems[n-1]
+		if guess.Valid {
+			problem.Guesses[guess.String] = count
+			problem.Attempts += count
+			if past {
+				problem.Correct += correct
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range results.Problems {
+		results.Problems[i].Accuracy = ratio(results.Problems[i].Correct, results.Problems[i].Attempts)
+	}
+
+	return results, nil
+}
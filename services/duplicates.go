@@ -0,0 +1,262 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"vibecheck/fingerprint"
+	"vibecheck/models"
+
+	"github.com/lib/pq"
+)
+
+const (
+	DuplicateWarn   = "warn"
+	DuplicateReject = "reject"
+)
+
+var (
+	ErrInvalidDistance = errors.New("distance must be between 0 and 64")
+	ErrDuplicateTweet  = errors.New("text duplicates an existing tweet")
+)
+
+// textHashIndex is the unique index keeping two tweets from being created with the same
+// text, should they pass the duplicate check concurrently
+const textHashIndex = "tweets_text_hash_unique_idx"
+
+// maxDuplicateMatches caps how many existing tweets are reported for a new one
+const maxDuplicateMatches = 5
+
+// storedBands is how many bands of its SimHash a tweet is stored with. Tweets sharing no
+// band are more than storedBands-1 bits apart, so up to that distance only tweets sharing
+// a band need comparing.
+const storedBands = 8
+
+// DuplicateError is returned when a new tweet duplicates existing ones
+type DuplicateError struct {
+	Matches []models.DuplicateMatch
+}
+
+func (e *DuplicateError) Error() string {
+	if len(e.Matches) > 0 && e.Matches[0].Exact {
+		return "text duplicates an existing tweet"
+	}
+	return "text is a near duplicate of an existing tweet"
+}
+
+// fingerprintOf returns the exact hash, SimHash and SimHash bands stored for a text, or
+// nils for texts with no words left after normalization, which are not fingerprinted
+func fingerprintOf(text string) (*string, *int64, pq.Int64Array) {
+	if fingerprint.Normalize(text) == "" {
+		return nil, nil, nil
+	}
+	hash := fingerprint.Exact(text)
+	sim := fingerprint.SimHash(text)
+	signed := int64(sim)
+	return &hash, &signed, bandKeys(sim)
+}
+
+// bandKeys returns the stored bands of a SimHash, each with its index in the high bits
+func bandKeys(sim uint64) pq.Int64Array {
+	bands := fingerprint.Bands(sim, storedBands-1)
+	keys := make(pq.Int64Array, len(bands))
+	for i, band := range bands {
+		keys[i] = int64(band.Index)<<32 | int64(band.Bits)
+	}
+	return keys
+}
+
+// duplicateViolation turns a violation of the unique text hash into ErrDuplicateTweet
+func duplicateViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == textHashIndex {
+		return ErrDuplicateTweet
+	}
+	return err
+}
+
+// findDuplicates retrieves existing tweets that duplicate a text, exact duplicates first
+func (s *VibecheckService) findDuplicates(text string) ([]models.DuplicateMatch, error) {
+	hash, sim, bands := fingerprintOf(text)
+	if hash == nil {
+		return nil, nil
+	}
+
+	// Distances are only computed for tweets sharing a band, unless the configured distance
+	// is too large for the stored bands to find every near duplicate
+	args := []interface{}{*hash, *sim, s.cfg.Duplicates.MaxDistance, maxDuplicateMatches}
+	near := "bit_count((simhash # $2)::bit(64)) <= $3"
+	if s.cfg.Duplicates.MaxDistance < storedBands {
+		near = "simhash_bands && $5 AND " + near
+		args = append(args, bands)
+	}
+	query := fmt.Sprintf(`SELECT id, text, status, bit_count((simhash # $2)::bit(64)) AS distance, text_hash = $1 AS exact
+		FROM tweets
+		WHERE text_hash = $1 OR (%s)
+		ORDER BY exact DESC, distance
+		LIMIT $4`, near)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []models.DuplicateMatch
+	for rows.Next() {
+		var match models.DuplicateMatch
+		if err := rows.Scan(&match.TweetID, &match.Text, &match.Status, &match.Distance, &match.Exact); err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+// checkDuplicates rejects texts duplicating existing tweets exactly, and near duplicates
+// when configured to. Otherwise near duplicates are returned as warnings.
+func (s *VibecheckService) checkDuplicates(text string) ([]models.DuplicateMatch, error) {
+	matches, err := s.findDuplicates(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	if matches[0].Exact || s.cfg.Duplicates.NearAction == DuplicateReject {
+		return nil, &DuplicateError{Matches: matches}
+	}
+	return matches, nil
+}
+
+// BackfillFingerprints fingerprints tweets stored without one, such as the seeded dataset.
+// It runs at startup so reads never have to. Exact duplicates of tweets fingerprinted before
+// them are marked as known, so they keep their hash without breaking its uniqueness.
+func (s *VibecheckService) BackfillFingerprints() (int, error) {
+	rows, err := s.db.Query("SELECT id, text FROM tweets WHERE text_hash IS NULL OR simhash_bands IS NULL")
+	if err != nil {
+		return 0, err
+	}
+	type pending struct{ id, text string }
+	var tweets []pending
+	for rows.Next() {
+		var tweet pending
+		if err := rows.Scan(&tweet.id, &tweet.text); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		tweets = append(tweets, tweet)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	filled := 0
+	for _, tweet := range tweets {
+		hash, sim, bands := fingerprintOf(tweet.text)
+		if hash == nil {
+			continue
+		}
+		query := `UPDATE tweets SET text_hash = $1, simhash = $2, simhash_bands = $3,
+				known_duplicate = EXISTS (SELECT 1 FROM tweets WHERE text_hash = $1 AND NOT known_duplicate AND id <> $4)
+			WHERE id = $4`
+		if _, err := s.db.Exec(query, *hash, *sim, bands, tweet.id); err != nil {
+			return filled, err
+		}
+		filled++
+	}
+	return filled, nil
+}
+
+// fingerprinted is a tweet loaded for clustering
+type fingerprinted struct {
+	models.DuplicateTweet
+	hash string
+	sim  uint64
+}
+
+// GetDuplicateClusters groups existing tweets into clusters of exact and near duplicates,
+// largest clusters first. Tweets are linked when their SimHashes are within maxDistance.
+// Tweets the startup backfill has not fingerprinted yet are left out.
+func (s *VibecheckService) GetDuplicateClusters(maxDistance int) ([]models.DuplicateCluster, error) {
+	if maxDistance < 0 || maxDistance > 64 {
+		return nil, ErrInvalidDistance
+	}
+
+	rows, err := s.db.Query("SELECT id, text, status, text_hash, simhash FROM tweets WHERE text_hash IS NOT NULL ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tweets []fingerprinted
+	for rows.Next() {
+		var tweet fingerprinted
+		var sim int64
+		if err := rows.Scan(&tweet.ID, &tweet.Text, &tweet.Status, &tweet.hash, &sim); err != nil {
+			return nil, err
+		}
+		tweet.sim = uint64(sim)
+		tweets = append(tweets, tweet)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Union tweets sharing a band whose hashes are close enough
+	parent := make([]int, len(tweets))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	buckets := map[fingerprint.Band][]int{}
+	for i, tweet := range tweets {
+		for _, band := range fingerprint.Bands(tweet.sim, maxDistance) {
+			for _, j := range buckets[band] {
+				if find(i) != find(j) && (tweet.hash == tweets[j].hash || fingerprint.Distance(tweet.sim, tweets[j].sim) <= maxDistance) {
+					parent[find(i)] = find(j)
+				}
+			}
+			buckets[band] = append(buckets[band], i)
+		}
+	}
+
+	members := map[int][]int{}
+	for i := range tweets {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	clusters := []models.DuplicateCluster{}
+	for _, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+		cluster := models.DuplicateCluster{Exact: true}
+		for _, i := range group {
+			cluster.Tweets = append(cluster.Tweets, tweets[i].DuplicateTweet)
+			if tweets[i].hash != tweets[group[0]].hash {
+				cluster.Exact = false
+			}
+			for _, j := range group {
+				if d := fingerprint.Distance(tweets[i].sim, tweets[j].sim); d > cluster.MaxDistance {
+					cluster.MaxDistance = d
+				}
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].Tweets) != len(clusters[j].Tweets) {
+			return len(clusters[i].Tweets) > len(clusters[j].Tweets)
+		}
+		return clusters[i].Tweets[0].ID < clusters[j].Tweets[0].ID
+	})
+	return clusters, nil
+}
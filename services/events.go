@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"vibecheck/models"
+
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+// Live events published to GET /events/stream
+const (
+	EventProblemPublished = "problem_published"
+	EventLeaderboard      = "leaderboard"
+	EventDailyLive        = "daily_live"
+	EventDailyAnswers     = "daily_answers"
+)
+
+const (
+	// eventsStream keeps recent events so clients can resume where they left off, and
+	// eventsChannel fans new events out to every replica
+	eventsStream  = "events"
+	eventsChannel = "events_live"
+)
+
+var ErrInvalidEventID = errors.New("invalid Last-Event-ID")
+
+// publishEvent appends an event to the event stream and broadcasts it. Events are best
+// effort: failing to publish one does not fail the action that caused it.
+func (s *VibecheckService) publishEvent(eventType string, data interface{}) {
+	ctx := context.Background()
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	event := models.Event{Type: eventType, Data: dataJSON, At: time.Now().UTC()}
+
+	event.ID, err = s.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: eventsStream,
+		MaxLen: s.cfg.Events.Retention,
+		Approx: true,
+		Values: map[string]interface{}{"type": event.Type, "data": string(event.Data), "at": event.At.Format(time.RFC3339Nano)},
+	}).Result()
+	if err != nil {
+		log.Printf("Failed to store %s event: %v\n", eventType, err)
+		return
+	}
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if err := s.redis.Publish(ctx, eventsChannel, eventJSON).Err(); err != nil {
+		log.Printf("Failed to publish %s event: %v\n", eventType, err)
+	}
+}
+
+// eventFromStream rebuilds an event stored in the event stream
+func eventFromStream(message redis.XMessage) models.Event {
+	event := models.Event{ID: message.ID}
+	event.Type, _ = message.Values["type"].(string)
+	data, _ := message.Values["data"].(string)
+	event.Data = json.RawMessage(data)
+	if at, ok := message.Values["at"].(string); ok {
+		event.At, _ = time.Parse(time.RFC3339Nano, at)
+	}
+	return event
+}
+
+// parseEventID splits a stream ID into its millisecond time and sequence number
+func parseEventID(id string) (uint64, uint64, bool) {
+	ms, seq, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	msValue, err := strconv.ParseUint(ms, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seqValue, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return msValue, seqValue, true
+}
+
+// eventAfter reports whether event ID a comes after b in the event stream
+func eventAfter(a, b string) bool {
+	aMs, aSeq, _ := parseEventID(a)
+	bMs, bSeq, _ := parseEventID(b)
+	return aMs > bMs || (aMs == bMs && aSeq > bSeq)
+}
+
+// SubscribeEvents streams live events until the context is done. With the ID of the last
+// event a client received, the events it missed that are still in the event stream are
+// replayed first.
+func (s *VibecheckService) SubscribeEvents(ctx context.Context, lastEventID string) (<-chan models.Event, error) {
+	if lastEventID != "" {
+		if _, _, ok := parseEventID(lastEventID); !ok {
+			return nil, ErrInvalidEventID
+		}
+	}
+
+	// Subscribe before replaying so no event falls between the two
+	pubsub := s.redis.Subscribe(ctx, eventsChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+	var missed []redis.XMessage
+	if lastEventID != "" {
+		var err error
+		missed, err = s.redis.XRange(ctx, eventsStream, "("+lastEventID, "+").Result()
+		if err != nil {
+			pubsub.Close()
+			return nil, err
+		}
+	}
+
+	events := make(chan models.Event)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+		send := func(event models.Event) bool {
+			select {
+			case events <- event:
+				lastEventID = event.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, message := range missed {
+			if !send(eventFromStream(message)) {
+				return
+			}
+		}
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event models.Event
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					continue
+				}
+				// Skip events already replayed
+				if lastEventID != "" && !eventAfter(event.ID, lastEventID) {
+					continue
+				}
+				if !send(event) {
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// announcePublished publishes an event for each tweet that was just published
+func (s *VibecheckService) announcePublished(ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	query := "SELECT id, text, COALESCE(collection, '') FROM tweets WHERE id = ANY($1::uuid[]) AND status = $2"
+	rows, err := s.db.Query(query, pq.Array(ids), StatusPublished)
+	if err != nil {
+		log.Printf("Failed to announce published tweets: %v\n", err)
+		return
+	}
+	defer rows.Close()
+
+	var published []models.ProblemPublishedEvent
+	for rows.Next() {
+		var event models.ProblemPublishedEvent
+		if err := rows.Scan(&event.TweetID, &event.Text, &event.Collection); err != nil {
+			log.Printf("Failed to announce published tweets: %v\n", err)
+			return
+		}
+		published = append(published, event)
+	}
+	for _, event := range published {
+		s.publishEvent(EventProblemPublished, event)
+	}
+}
+
+// announceLeaderboard publishes a player's points with their standing on today's global leaderboard
+func (s *VibecheckService) announceLeaderboard(playerID, collection string, points int) {
+	ctx := context.Background()
+	id, _ := periodID(PeriodDaily, time.Now())
+	key := leaderboardKey(PeriodDaily, id, "")
+	event := models.LeaderboardEvent{PlayerID: playerID, Points: points, Collection: collection}
+	score, err := s.redis.ZScore(ctx, key, playerID).Result()
+	if err != nil {
+		return
+	}
+	rank, err := s.redis.ZRevRank(ctx, key, playerID).Result()
+	if err != nil {
+		return
+	}
+	event.DailyScore = int(score)
+	event.DailyRank = int(rank) + 1
+	s.publishEvent(EventLeaderboard, event)
+}
+
+// announceDailyAnswers publishes how many players gave each answer to a daily challenge problem so far
+func (s *VibecheckService) announceDailyAnswers(day, tweetID string) {
+	query := "SELECT guess, COUNT(*) FROM daily_challenge_attempts WHERE day = $1 AND tweet_id = $2 GROUP BY guess"
+	rows, err := s.db.Query(query, day, tweetID)
+	if err != nil {
+		log.Printf("Failed to announce daily answers: %v\n", err)
+		return
+	}
+	defer rows.Close()
+
+	event := models.DailyAnswersEvent{Day: day, TweetID: tweetID, Counts: map[string]int{}}
+	for _, label := range models.Labels {
+		event.Counts[label] = 0
+	}
+	for rows.Next() {
+		var guess string
+		var count int
+		if err := rows.Scan(&guess, &count); err != nil {
+			log.Printf("Failed to announce daily answers: %v\n", err)
+			return
+		}
+		event.Counts[guess] = count
+		event.Total += count
+	}
+	s.publishEvent(EventDailyAnswers, event)
+}
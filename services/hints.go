@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"vibecheck/models"
+	"vibecheck/sentiment"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrHintNotGenerated = errors.New("tweet has no generated hint to accept")
+	ErrHintRequired     = errors.New("a hint is required")
+)
+
+// generateHint derives a hint from a tweet's text and stores it marked as generated,
+// unless a curator gave the tweet a hint in the meantime
+func (s *VibecheckService) generateHint(tweet *models.Tweet) (string, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	hint := sentiment.Hint(tweet.Text)
+	query := `UPDATE tweets SET hint = $1, hint_generated = TRUE WHERE id = $2 AND COALESCE(hint, '') = ''
+		RETURNING hint`
+	err = tx.QueryRow(query, hint, tweet.ID).Scan(&hint)
+	if err == sql.ErrNoRows {
+		// Someone else stored a hint first
+		err = tx.QueryRow("SELECT COALESCE(hint, '') FROM tweets WHERE id = $1", tweet.ID).Scan(&hint)
+	} else if err == nil {
+		err = recordTweetUpdated(tx, tweet.ID)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	s.redis.Del(ctx, "tweet_"+tweet.ID)
+	return hint, nil
+}
+
+// GenerateMissingHints generates and stores hints for every tweet without one
+func (s *VibecheckService) GenerateMissingHints() (int, error) {
+	rows, err := s.db.Query("SELECT id, text FROM tweets WHERE COALESCE(hint, '') = ''")
+	if err != nil {
+		return 0, err
+	}
+	var tweets []models.Tweet
+	for rows.Next() {
+		var tweet models.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.Text); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		tweets = append(tweets, tweet)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for i := range tweets {
+		if _, err := s.generateHint(&tweets[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(tweets), nil
+}
+
+// GetGeneratedHints retrieves the generated hints no curator has accepted or overwritten yet
+func (s *VibecheckService) GetGeneratedHints() ([]models.GeneratedHint, error) {
+	rows, err := s.db.Query("SELECT id, text, hint, status FROM tweets WHERE hint_generated ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hints := []models.GeneratedHint{}
+	for rows.Next() {
+		var hint models.GeneratedHint
+		if err := rows.Scan(&hint.TweetID, &hint.Text, &hint.Hint, &hint.Status); err != nil {
+			return nil, err
+		}
+		hints = append(hints, hint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return hints, nil
+}
+
+// setHint runs a hint update, telling a missing tweet apart from one the update did not apply to
+func (s *VibecheckService) setHint(tweetID, query string, args ...interface{}) (*models.Tweet, error) {
+	if _, err := uuid.Parse(tweetID); err != nil {
+		return nil, ErrTweetNotFound
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		tx.Rollback()
+		tweet, err := s.GetTweet(tweetID)
+		if err != nil {
+			return nil, err
+		}
+		if tweet == nil {
+			return nil, ErrTweetNotFound
+		}
+		return nil, ErrHintNotGenerated
+	}
+	if err := recordTweetUpdated(tx, tweetID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	s.redis.Del(ctx, "tweet_"+tweetID)
+	return s.GetTweet(tweetID)
+}
+
+// AcceptHint keeps a tweet's generated hint as its curated hint
+func (s *VibecheckService) AcceptHint(tweetID string) (*models.Tweet, error) {
+	return s.setHint(tweetID, "UPDATE tweets SET hint_generated = FALSE WHERE id = $1 AND hint_generated", tweetID)
+}
+
+// OverwriteHint replaces a tweet's hint, generated or not, with a curated one
+func (s *VibecheckService) OverwriteHint(tweetID, hint string) (*models.Tweet, error) {
+	if hint == "" {
+		return nil, ErrHintRequired
+	}
+	if err := s.hintSafety.Run(hint).Err(); err != nil {
+		return nil, err
+	}
+	return s.setHint(tweetID, "UPDATE tweets SET hint = $1, hint_generated = FALSE WHERE id = $2", hint, tweetID)
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"vibecheck/agreement"
+	"vibecheck/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ConsensusMajority = "majority"
+	ConsensusWeighted = "weighted"
+)
+
+var ErrVoteRequiresPlayer = errors.New("labeling votes require a player ID")
+
+// voteTally holds the votes cast on one labeling item
+type voteTally struct {
+	counts  map[string]int
+	weights map[string]float64
+}
+
+// recordVote stores a player's label vote on a tweet without a gold answer
+func (s *VibecheckService) recordVote(playerID, tweetID, label string) error {
+	if playerID == "" {
+		return ErrVoteRequiresPlayer
+	}
+	query := "INSERT INTO label_votes (tweet_id, player_id, label) VALUES ($1, $2, $3) ON CONFLICT (tweet_id, player_id) DO NOTHING"
+	res, err := s.db.Exec(query, tweetID, playerID, label)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrAlreadyAnswered
+	}
+	return nil
+}
+
+// GetLabelingProblem retrieves a random tweet without a gold answer for the viewer to label
+func (s *VibecheckService) GetLabelingProblem(opts QuizOptions) (*models.Problem, error) {
+	opts.Labeling = true
+	ids, err := s.drawProblems(opts, 1)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetProblem(ids[0])
+}
+
+// voteWeight is the weight of a vote under the configured consensus rule. Weighted votes
+// count by the voter's smoothed accuracy on gold problems, so unknown voters weigh 0.5.
+func (s *VibecheckService) voteWeight(correct, attempts int) float64 {
+	if s.cfg.Consensus.Rule == ConsensusWeighted {
+		return float64(correct+1) / float64(attempts+2)
+	}
+	return 1
+}
+
+// loadVotes tallies the label votes per tweet, weighted by the consensus rule: those on
+// one tweet, or every vote when tweetID is empty
+func (s *VibecheckService) loadVotes(tweetID string) (map[string]*voteTally, error) {
+	filter, args := "", []interface{}{}
+	if tweetID != "" {
+		filter, args = "WHERE tweet_id = $1", append(args, tweetID)
+	}
+	// Accuracy is only computed for the players who cast the selected votes
+	query := fmt.Sprintf(`SELECT v.tweet_id, v.label, COALESCE(p.correct, 0), COALESCE(p.attempts, 0)
+		FROM (SELECT tweet_id, player_id, label FROM label_votes %[1]s) v
+		LEFT JOIN (
+			SELECT player_id, COUNT(*) FILTER (WHERE correct) AS correct, COUNT(*) AS attempts
+			FROM attempts WHERE player_id IN (SELECT player_id FROM label_votes %[1]s) GROUP BY player_id
+		) p ON p.player_id = v.player_id`, filter)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tallies := map[string]*voteTally{}
+	for rows.Next() {
+		var tweetID, label string
+		var correct, attempts int
+		if err := rows.Scan(&tweetID, &label, &correct, &attempts); err != nil {
+			return nil, err
+		}
+		tally, ok := tallies[tweetID]
+		if !ok {
+			tally = &voteTally{counts: map[string]int{}, weights: map[string]float64{}}
+			tallies[tweetID] = tally
+		}
+		tally.counts[label]++
+		tally.weights[label] += s.voteWeight(correct, attempts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tallies, nil
+}
+
+// consensus picks the label with the most weight, if enough votes were cast and it holds
+// enough of the weight. Ties have no consensus.
+func (s *VibecheckService) consensus(tally *voteTally) (string, float64) {
+	votes := 0
+	for _, c := range tally.counts {
+		votes += c
+	}
+	total := 0.0
+	best, bestWeight, tied := "", 0.0, false
+	for _, label := range models.Labels {
+		weight := tally.weights[label]
+		total += weight
+		if weight > bestWeight {
+			best, bestWeight, tied = label, weight, false
+		} else if weight == bestWeight && weight > 0 {
+			tied = true
+		}
+	}
+	if total == 0 {
+		return "", 0
+	}
+	confidence := bestWeight / total
+	if tied || votes < s.cfg.Consensus.MinVotes || confidence < s.cfg.Consensus.MinAgreement {
+		return "", confidence
+	}
+	return best, confidence
+}
+
+// countsRow lays an item's vote counts out with one column per label
+func countsRow(tally *voteTally) ([]int, int) {
+	row := make([]int, len(models.Labels))
+	votes := 0
+	for i, label := range models.Labels {
+		row[i] = tally.counts[label]
+		votes += row[i]
+	}
+	return row, votes
+}
+
+// ratedMatrix lays out the vote counts of every item with at least two votes, the only
+// items that say anything about agreement
+func ratedMatrix(tallies map[string]*voteTally) [][]int {
+	matrix := make([][]int, 0, len(tallies))
+	for _, tally := range tallies {
+		if row, votes := countsRow(tally); votes >= 2 {
+			matrix = append(matrix, row)
+		}
+	}
+	return matrix
+}
+
+// ratedLabelTotals counts the votes for each label over the items with at least two votes,
+// from which the chance agreement of the dataset follows
+func (s *VibecheckService) ratedLabelTotals() ([]int, error) {
+	query := `SELECT label, COUNT(*) FROM label_votes
+		WHERE tweet_id IN (SELECT tweet_id FROM label_votes GROUP BY tweet_id HAVING COUNT(*) >= 2)
+		GROUP BY label`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var label string
+		var count int
+		if err := rows.Scan(&label, &count); err != nil {
+			return nil, err
+		}
+		counts[label] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	totals := make([]int, len(models.Labels))
+	for i, label := range models.Labels {
+		totals[i] = counts[label]
+	}
+	return totals, nil
+}
+
+// GetLabelingItem retrieves the votes, consensus label and agreement of one labeling item
+func (s *VibecheckService) GetLabelingItem(tweetID string) (*models.LabelingItem, error) {
+	if _, err := uuid.Parse(tweetID); err != nil {
+		return nil, ErrTweetNotFound
+	}
+	tweet, err := s.GetTweet(tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if tweet == nil || tweet.Status != StatusPublished {
+		return nil, ErrTweetNotFound
+	}
+
+	tallies, err := s.loadVotes(tweetID)
+	if err != nil {
+		return nil, err
+	}
+	tally, ok := tallies[tweetID]
+	if !ok {
+		tally = &voteTally{counts: map[string]int{}, weights: map[string]float64{}}
+	}
+
+	item := &models.LabelingItem{
+		TweetID: tweet.ID,
+		Text:    tweet.Text,
+		Votes:   tally.counts,
+		Weights: tally.weights,
+	}
+	var row []int
+	row, item.TotalVotes = countsRow(tally)
+	item.Consensus, item.Confidence = s.consensus(tally)
+	item.ItemAgreement = agreement.ItemAgreement(row)
+	if item.TotalVotes >= 2 {
+		// Per-item kappa corrects the item's agreement by the chance agreement of the whole dataset
+		totals, err := s.ratedLabelTotals()
+		if err != nil {
+			return nil, err
+		}
+		item.Kappa = agreement.Kappa(item.ItemAgreement, agreement.ExpectedAgreement([][]int{totals}))
+	}
+	return item, nil
+}
+
+// HasVoted reports whether a player has voted on a labeling item
+func (s *VibecheckService) HasVoted(playerID, tweetID string) (bool, error) {
+	if playerID == "" {
+		return false, nil
+	}
+	var voted bool
+	query := "SELECT EXISTS (SELECT 1 FROM label_votes WHERE tweet_id = $1 AND player_id = $2)"
+	if err := s.db.QueryRow(query, tweetID, playerID).Scan(&voted); err != nil {
+		return false, err
+	}
+	return voted, nil
+}
+
+// GetLabelingReport computes consensus coverage and agreement over every labeling vote
+func (s *VibecheckService) GetLabelingReport() (*models.LabelingReport, error) {
+	tallies, err := s.loadVotes("")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.LabelingReport{Rule: s.cfg.Consensus.Rule, Items: len(tallies)}
+	for _, tally := range tallies {
+		_, votes := countsRow(tally)
+		report.Votes += votes
+		if label, _ := s.consensus(tally); label != "" {
+			report.ConsensusItems++
+		}
+	}
+
+	matrix := ratedMatrix(tallies)
+	report.RatedItems = len(matrix)
+	observed := 0.0
+	for _, row := range matrix {
+		observed += agreement.ItemAgreement(row)
+	}
+
+	report.ObservedAgreement = ratioFloat(observed, report.RatedItems)
+	report.ExpectedAgreement = agreement.ExpectedAgreement(matrix)
+	report.FleissKappa = agreement.FleissKappa(matrix)
+	report.KrippendorffAlpha = agreement.KrippendorffAlpha(matrix)
+	return report, nil
+}
+
+func ratioFloat(part float64, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return part / float64(total)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"vibecheck/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodAllTime = "alltime"
+)
+
+var ErrInvalidPeriod = errors.New("invalid leaderboard period")
+
+// leaderboardRetention keeps a finished period around long enough to be read after it rolls over
+var leaderboardRetention = map[string]time.Duration{
+	PeriodDaily:  48 * time.Hour,
+	PeriodWeekly: 14 * 24 * time.Hour,
+}
+
+// periodID identifies the UTC period a moment falls in, so keys roll over on their own
+func periodID(period string, t time.Time) (string, error) {
+	t = t.UTC()
+	switch period {
+	case PeriodDaily:
+		return t.Format("2006-01-02"), nil
+	case PeriodWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week), nil
+	case PeriodAllTime:
+		return "all", nil
+	}
+	return "", ErrInvalidPeriod
+}
+
+func leaderboardKey(period, id, collection string) string {
+	scope := "global"
+	if collection != "" {
+		scope = "collection_" + collection
+	}
+	return "leaderboard_" + period + "_" + id + "_" + scope
+}
+
+// recordLeaderboardScore adds points to every period of the global and collection leaderboards
+// and announces the change
+func (s *VibecheckService) recordLeaderboardScore(playerID, collection string, points int) {
+	if playerID == "" || points <= 0 {
+		return
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	scopes := []string{""}
+	if collection != "" {
+		scopes = append(scopes, collection)
+	}
+
+	_, err := s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, period := range []string{PeriodDaily, PeriodWeekly, PeriodAllTime} {
+			id, _ := periodID(period, now)
+			for _, scope := range scopes {
+				key := leaderboardKey(period, id, scope)
+				pipe.ZIncrBy(ctx, key, float64(points), playerID)
+				if retention, ok := leaderboardRetention[period]; ok {
+					pipe.Expire(ctx, key, retention)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to update leaderboards for %s: %v\n", playerID, err)
+		return
+	}
+	s.announceLeaderboard(playerID, collection, points)
+}
+
+// GetLeaderboard retrieves the top players of the current period, with the caller's own rank when known
+func (s *VibecheckService) GetLeaderboard(period, collection, playerID string, limit int) (*models.Leaderboard, error) {
+	id, err := periodID(period, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	key := leaderboardKey(period, id, collection)
+
+	scores, err := s.redis.ZRevRangeWithScores(ctx, key, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := &models.Leaderboard{
+		Period:     period,
+		PeriodID:   id,
+		Collection: collection,
+		Entries:    make([]models.LeaderboardEntry, 0, len(scores)),
+	}
+	for i, z := range scores {
+		leaderboard.Entries = append(leaderboard.Entries, models.LeaderboardEntry{
+			Rank:     int64(i + 1),
+			PlayerID: z.Member.(string),
+			Score:    int64(z.Score),
+		})
+	}
+
+	if playerID != "" {
+		rank, err := s.redis.ZRevRank(ctx, key, playerID).Result()
+		if err == nil {
+			score, err := s.redis.ZScore(ctx, key, playerID).Result()
+			if err != nil {
+				return nil, err
+			}
+			leaderboard.Me = &models.LeaderboardEntry{Rank: rank + 1, PlayerID: playerID, Score: int64(score)}
+		} else if err != redis.Nil {
+			return nil, err
+		}
+	}
+
+	return leaderboard, nil
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+	"vibecheck/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusDraft     = "draft"
+	StatusScheduled = "scheduled"
+	StatusPending   = "pending"
+	StatusPublished = "published"
+	StatusRejected  = "rejected"
+	StatusArchived  = "archived"
+)
+
+// statusTransitions lists the statuses a tweet may move to from each status. Scheduled
+// tweets may be rescheduled with a new publish time. Pending submissions only leave
+// moderation through the moderation routes.
+var statusTransitions = map[string][]string{
+	StatusDraft:     {StatusScheduled, StatusPublished, StatusArchived},
+	StatusScheduled: {StatusScheduled, StatusDraft, StatusPublished, StatusArchived},
+	StatusPublished: {StatusDraft, StatusArchived},
+	StatusArchived:  {StatusDraft, StatusPublished},
+	StatusRejected:  {StatusDraft},
+}
+
+var (
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidTransition = errors.New("status transition not allowed")
+	ErrInvalidPublishAt  = errors.New("scheduled tweets need a publishAt in the future")
+)
+
+// validateInitialStatus checks the status a tweet is created with
+func validateInitialStatus(status string, publishAt *time.Time) error {
+	switch status {
+	case StatusDraft, StatusPublished:
+		return nil
+	case StatusScheduled:
+		if publishAt == nil || !publishAt.After(time.Now()) {
+			return ErrInvalidPublishAt
+		}
+		return nil
+	}
+	return ErrInvalidStatus
+}
+
+func canTransition(from, to string) bool {
+	for _, status := range statusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeTweetStatus moves a tweet through its lifecycle, validating the transition
+func (s *VibecheckService) ChangeTweetStatus(id string, change *models.StatusChange) (*models.Tweet, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTweetNotFound
+	}
+	if _, ok := statusTransitions[change.Status]; !ok && change.Status != StatusPending {
+		return nil, ErrInvalidStatus
+	}
+
+	var publishAt *time.Time
+	if change.Status == StatusScheduled {
+		if change.PublishAt == nil || !change.PublishAt.After(time.Now()) {
+			return nil, ErrInvalidPublishAt
+		}
+		publishAt = change.PublishAt
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var current string
+	if err := tx.QueryRow("SELECT status FROM tweets WHERE id = $1 FOR UPDATE", id).Scan(&current); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTweetNotFound
+		}
+		return nil, err
+	}
+	if !canTransition(current, change.Status) {
+		return nil, ErrInvalidTransition
+	}
+
+	if _, err := tx.Exec("UPDATE tweets SET status = $1, publish_at = $2 WHERE id = $3", change.Status, publishAt, id); err != nil {
+		return nil, err
+	}
+	event := models.TweetStatusChangedEvent{TweetID: id, From: current, To: change.Status, PublishAt: publishAt}
+	if err := recordOutboxEvent(tx, id, OutboxTweetStatusChanged, event); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	s.redis.Del(ctx, "tweet_"+id, "problem_"+id)
+	s.invalidateProblemLists()
+	if change.Status == StatusPublished {
+		s.announcePublished(id)
+	}
+
+	return s.GetTweet(id)
+}
+
+// PublishDueTweets publishes every scheduled tweet whose publish time has passed
+func (s *VibecheckService) PublishDueTweets() ([]string, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE tweets SET status = $1 WHERE status = $2 AND publish_at <= NOW() RETURNING id"
+	rows, err := tx.Query(query, StatusPublished, StatusScheduled)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		event := models.TweetStatusChangedEvent{TweetID: id, From: StatusScheduled, To: StatusPublished}
+		if err := recordOutboxEvent(tx, id, OutboxTweetStatusChanged, event); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	ctx := context.Background()
+	for _, id := range ids {
+		s.redis.Del(ctx, "tweet_"+id, "problem_"+id)
+	}
+	s.invalidateProblemLists()
+	s.announcePublished(ids...)
+	return ids, nil
+}
+
+// RunScheduler publishes due scheduled tweets at the configured interval until ctx is done
+func (s *VibecheckService) RunScheduler(ctx context.Context) {
+	if s.cfg.SchedulerInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.cfg.SchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Choosing today's challenge as soon as the day starts announces it going live
+			if _, err := s.dailyChallengeIDs(today()); err != nil {
+				log.Printf("Daily challenge selection failed: %v\n", err)
+			}
+			ids, err := s.PublishDueTweets()
+			if err != nil {
+				log.Printf("Scheduled publishing failed: %v\n", err)
+				continue
+			}
+			if len(ids) > 0 {
+				log.Printf("Published %d scheduled tweets\n", len(ids))
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"vibecheck/models"
+
+	"github.com/google/uuid"
+)
+
+const allProblemsQuery = "SELECT id, text FROM tweets WHERE status = 'published'"
+
+var (
+	ErrNotPending     = errors.New("submission is not pending moderation")
+	ErrReasonRequired = errors.New("a reason is required to reject a submission")
+)
+
+// invalidateProblemLists drops the cached problem lists, which only hold published tweets
+func (s *VibecheckService) invalidateProblemLists() {
+	ctx := context.Background()
+	keys := []string{allProblemsQuery}
+	iter := s.redis.Scan(ctx, 0, "problems_page_*", 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	s.redis.Del(ctx, keys...)
+}
+
+// GetModerationQueue retrieves the submissions waiting for moderation, oldest first
+func (s *VibecheckService) GetModerationQueue() ([]models.Submission, error) {
+	query := `SELECT id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status, COALESCE(safety_flags, '[]'), created_at
+		FROM tweets WHERE status = $1 ORDER BY created_at`
+	rows, err := s.db.Query(query, StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	submissions := []models.Submission{}
+	for rows.Next() {
+		var submission models.Submission
+		var flags []byte
+		if err := rows.Scan(&submission.ID, &submission.Text, &submission.Hint, &submission.Answer, &submission.Collection, &submission.Status, &flags, &submission.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(flags, &submission.Flags); err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, submission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// moderate moves a pending submission to a new status, recording who decided and why
+func (s *VibecheckService) moderate(id, status, reason, moderator string) (*models.Submission, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTweetNotFound
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE tweets SET status = $1, moderation_reason = NULLIF($2, ''), moderated_by = NULLIF($3, ''), moderated_at = NOW()
+		WHERE id = $4 AND status = $5
+		RETURNING id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status,
+			COALESCE(moderation_reason, ''), COALESCE(moderated_by, ''), moderated_at, created_at`
+	row := tx.QueryRow(query, status, reason, moderator, id, StatusPending)
+
+	var submission models.Submission
+	err = row.Scan(&submission.ID, &submission.Text, &submission.Hint, &submission.Answer, &submission.Collection, &submission.Status,
+		&submission.Reason, &submission.ModeratedBy, &submission.ModeratedAt, &submission.CreatedAt)
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM tweets WHERE id = $1)", id).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrTweetNotFound
+		}
+		return nil, ErrNotPending
+	}
+	if err != nil {
+		return nil, err
+	}
+	event := models.TweetStatusChangedEvent{TweetID: id, From: StatusPending, To: status}
+	if err := recordOutboxEvent(tx, id, OutboxTweetStatusChanged, event); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	s.redis.Del(ctx, "tweet_"+id, "problem_"+id)
+	s.invalidateProblemLists()
+	if status == StatusPublished {
+		s.announcePublished(id)
+	}
+
+	return &submission, nil
+}
+
+// ApproveSubmission publishes a pending submission
+func (s *VibecheckService) ApproveSubmission(id, moderator string) (*models.Submission, error) {
+	return s.moderate(id, StatusPublished, "", moderator)
+}
+
+// RejectSubmission rejects a pending submission with a reason
+func (s *VibecheckService) RejectSubmission(id, moderator, reason string) (*models.Submission, error) {
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	return s.moderate(id, StatusRejected, reason, moderator)
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+	"vibecheck/config"
+	"vibecheck/models"
+	"vibecheck/outbox"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Domain events recorded in the outbox
+const (
+	OutboxTweetCreated       = "tweet.created"
+	OutboxTweetUpdated       = "tweet.updated"
+	OutboxTweetDeleted       = "tweet.deleted"
+	OutboxTweetStatusChanged = "tweet.status_changed"
+	OutboxTweetFlagged       = "tweet.flagged"
+	OutboxRoundCompleted     = "round.completed"
+)
+
+const (
+	aggregateTweet = "tweet"
+	aggregateRound = "round"
+	// outboxBatch is how many events the relay publishes per transaction
+	outboxBatch = 100
+	// outboxLock keys the advisory lock that keeps a single replica relaying at a time,
+	// so events are published in the order they were recorded
+	outboxLock = 0x6f7574626f78
+)
+
+// newOutboxSink creates the configured sink: a Redis stream by default, an HTTP endpoint,
+// or the application log
+func newOutboxSink(cfg config.Config, redisClient *redis.Client) outbox.Sink {
+	switch cfg.Outbox.Sink {
+	case "log":
+		return outbox.Log{}
+	case "webhook":
+		if cfg.Outbox.URL != "" {
+			return outbox.NewHTTP(cfg.Outbox.URL, cfg.Outbox.Timeout)
+		}
+		log.Printf("OUTBOX_URL is not set, relaying outbox events to the log\n")
+		return outbox.Log{}
+	case "redis":
+	default:
+		log.Printf("Unknown outbox sink %q, relaying outbox events to a Redis stream\n", cfg.Outbox.Sink)
+	}
+	return outbox.NewRedisStream(redisClient, cfg.Outbox.Stream, cfg.Outbox.StreamLength)
+}
+
+// recordOutboxEvent records a domain event about a tweet in the transaction changing it
+func recordOutboxEvent(tx *sql.Tx, tweetID, eventType string, payload interface{}) error {
+	return recordAggregateEvent(tx, aggregateTweet, tweetID, eventType, payload)
+}
+
+// recordAggregateEvent records a domain event about any aggregate in the transaction changing it
+func recordAggregateEvent(tx *sql.Tx, aggregate, aggregateID, eventType string, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO outbox (id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)"
+	_, err = tx.Exec(query, generateNewID(), aggregate, aggregateID, eventType, payloadJSON)
+	return err
+}
+
+// tweetInTx reads a tweet as the transaction sees it, or nil if there is no such tweet
+func tweetInTx(tx *sql.Tx, id string) (*models.Tweet, error) {
+	query := "SELECT id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status, publish_at, hint_generated FROM tweets WHERE id = $1"
+	var tweet models.Tweet
+	err := tx.QueryRow(query, id).Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer, &tweet.Collection, &tweet.Status, &tweet.PublishAt, &tweet.HintGenerated)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tweet, nil
+}
+
+// recordTweetUpdated records a tweet.updated event with the tweet as the transaction left it,
+// for changes made outside UpdateTweet like relabels and hint edits
+func recordTweetUpdated(tx *sql.Tx, tweetID string) error {
+	tweet, err := tweetInTx(tx, tweetID)
+	if err != nil || tweet == nil {
+		return err
+	}
+	return recordOutboxEvent(tx, tweetID, OutboxTweetUpdated, tweet)
+}
+
+// applyOutboxEvent brings Redis in line with a committed change. It runs before an event
+// is published and again whenever it is retried, so a crash between a commit and the
+// cache update cannot leave stale caches behind.
+func (s *VibecheckService) applyOutboxEvent(ctx context.Context, event outbox.Event) {
+	if event.Aggregate != aggregateTweet {
+		return
+	}
+	s.redis.Del(ctx, "tweet_"+event.AggregateID, "problem_"+event.AggregateID)
+	s.invalidateProblemLists()
+}
+
+// outboxBackoff is how long to wait before retrying an event that failed attempts times,
+// doubling from the base delay up to the maximum
+func (s *VibecheckService) outboxBackoff(attempts int) time.Duration {
+	delay := s.cfg.Outbox.BackoffBase
+	for i := 1; i < attempts && delay < s.cfg.Outbox.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, s.cfg.Outbox.BackoffMax)
+}
+
+// RelayOutbox publishes unpublished outbox events to the sink in the order they were
+// recorded, queueing their webhook deliveries the first time it gets to them. A failed
+// event is retried with backoff before any later event, until the sink rejects it for good
+// or it runs out of attempts; it is then marked failed and skipped.
+// Events are marked published in the same transaction that claimed them, so an event may
+// be published again if that transaction fails, but never lost.
+func (s *VibecheckService) RelayOutbox(ctx context.Context) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRow("SELECT pg_try_advisory_xact_lock($1)", outboxLock).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if !locked {
+		// Another replica is relaying
+		return 0, nil
+	}
+
+	query := `SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at, attempts,
+			COALESCE(next_attempt_at <= NOW(), TRUE)
+		FROM outbox
+		WHERE published_at IS NULL AND failed_at IS NULL
+		ORDER BY created_at, id
+		LIMIT $1`
+	rows, err := tx.Query(query, outboxBatch)
+	if err != nil {
+		return 0, err
+	}
+	type pendingEvent struct {
+		outbox.Event
+		attempts int
+		due      bool
+	}
+	var events []pendingEvent
+	for rows.Next() {
+		var event pendingEvent
+		var payload []byte
+		if err := rows.Scan(&event.ID, &event.Aggregate, &event.AggregateID, &event.Type, &payload, &event.CreatedAt, &event.attempts, &event.due); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	published := 0
+	webhooksQueued := false
+	for _, event := range events {
+		if !event.due {
+			// Waiting to retry, and later events wait behind it
+			break
+		}
+		s.applyOutboxEvent(ctx, event.Event)
+		if event.attempts == 0 {
+			// Webhooks get their own deliveries, queued once whatever happens to the sink
+			queued, err := queueWebhookDeliveries(tx, event.Event)
+			if err != nil {
+				return 0, err
+			}
+			webhooksQueued = webhooksQueued || queued
+		}
+		if err := s.outboxSink.Publish(ctx, event.Event); err != nil {
+			attempts := event.attempts + 1
+			if errors.Is(err, outbox.ErrRejected) || attempts >= s.cfg.Outbox.MaxAttempts {
+				query = "UPDATE outbox SET attempts = $1, last_error = $2, failed_at = NOW() WHERE id = $3"
+				if _, err := tx.Exec(query, attempts, err.Error(), event.ID); err != nil {
+					return 0, err
+				}
+				log.Printf("Gave up publishing outbox event %s to %s after %d attempts: %v\n", event.ID, s.outboxSink.Name(), attempts, err)
+				continue
+			}
+			query = "UPDATE outbox SET attempts = $1, last_error = $2, next_attempt_at = NOW() + make_interval(secs => $3) WHERE id = $4"
+			if _, err := tx.Exec(query, attempts, err.Error(), s.outboxBackoff(attempts).Seconds(), event.ID); err != nil {
+				return 0, err
+			}
+			log.Printf("Failed to publish outbox event %s to %s: %v\n", event.ID, s.outboxSink.Name(), err)
+			break
+		}
+		query = "UPDATE outbox SET attempts = attempts + 1, last_error = NULL, published_at = NOW() WHERE id = $1"
+		if _, err := tx.Exec(query, event.ID); err != nil {
+			return 0, err
+		}
+		published++
+	}
+
+	// Published events are kept for a while for troubleshooting
+	if s.cfg.Outbox.Retention > 0 {
+		query = "DELETE FROM outbox WHERE published_at < NOW() - make_interval(secs => $1)"
+		if _, err := tx.Exec(query, s.cfg.Outbox.Retention.Seconds()); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	if webhooksQueued {
+		s.wakeWebhookDispatcher()
+	}
+	return published, nil
+}
+
+// RunOutboxRelay relays outbox events at the configured interval until ctx is done
+func (s *VibecheckService) RunOutboxRelay(ctx context.Context) {
+	if s.cfg.Outbox.PollInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.cfg.Outbox.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Keep relaying while full batches come back, so a backlog drains quickly
+			for {
+				published, err := s.RelayOutbox(ctx)
+				if err != nil {
+					log.Printf("Outbox relay failed: %v\n", err)
+				}
+				if err != nil || published < outboxBatch {
+					break
+				}
+			}
+		}
+	}
+}
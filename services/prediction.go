@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"vibecheck/classifier"
+	"vibecheck/models"
+	"vibecheck/sentiment"
+
+	"github.com/google/uuid"
+)
+
+// GetPrediction classifies a tweet with the built-in sentiment classifier and compares the
+// prediction with the gold answer, when the tweet has one
+func (s *VibecheckService) GetPrediction(tweetID string) (*models.Prediction, error) {
+	if _, err := uuid.Parse(tweetID); err != nil {
+		return nil, ErrTweetNotFound
+	}
+	tweet, err := s.GetTweet(tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if tweet == nil {
+		return nil, ErrTweetNotFound
+	}
+
+	analysis := sentiment.Analyze(tweet.Text)
+	prediction := &models.Prediction{
+		TweetID: tweet.ID,
+		Model:   sentiment.Model,
+		Version: sentiment.Version,
+		Label:   analysis.Label,
+		Score:   analysis.Compound,
+		Answer:  tweet.Answer,
+		Words:   analysis.Words,
+	}
+	if tweet.Answer != "" {
+		correct := analysis.Label == tweet.Answer
+		prediction.Correct = &correct
+	}
+	return prediction, nil
+}
+
+// storedPrediction retrieves a classifier's stored prediction for a tweet, classifying
+// and storing it first if needed
+func (s *VibecheckService) storedPrediction(clf classifier.Classifier, tweet *models.Tweet) (classifier.Prediction, error) {
+	var prediction classifier.Prediction
+	query := "SELECT label, score FROM predictions WHERE tweet_id = $1 AND model = $2 AND version = $3"
+	err := s.db.QueryRow(query, tweet.ID, clf.Name(), clf.Version()).Scan(&prediction.Label, &prediction.Score)
+	if err == nil {
+		return prediction, nil
+	}
+	if err != sql.ErrNoRows {
+		return prediction, err
+	}
+
+	predictions, err := s.scoreTweets(context.Background(), clf, []models.Tweet{*tweet})
+	if err != nil {
+		return prediction, err
+	}
+	return predictions[0], nil
+}
+
+const insertPredictionQuery = `INSERT INTO predictions (tweet_id, model, version, label, score) VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (tweet_id, model, version) DO UPDATE SET label = EXCLUDED.label, score = EXCLUDED.score, created_at = NOW()`
+
+// recordLexiconPrediction stores the built-in classifier's prediction for a tweet in the
+// transaction writing its text, so quiz draws and versus stats only ever read predictions
+func recordLexiconPrediction(tx *sql.Tx, tweetID, text string) error {
+	prediction := sentiment.Classify(text)
+	_, err := tx.Exec(insertPredictionQuery, tweetID, sentiment.Model, sentiment.Version, prediction.Label, prediction.Compound)
+	return err
+}
+
+// scoreTweets classifies tweets in the classifier's batches and stores the predictions
+func (s *VibecheckService) scoreTweets(ctx context.Context, clf classifier.Classifier, tweets []models.Tweet) ([]classifier.Prediction, error) {
+	texts := make([]string, len(tweets))
+	for i, tweet := range tweets {
+		texts[i] = tweet.Text
+	}
+	predictions, err := classifier.ClassifyAll(ctx, clf, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for i, prediction := range predictions {
+		if _, err := tx.Exec(insertPredictionQuery, tweets[i].ID, clf.Name(), clf.Version(), prediction.Label, prediction.Score); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return predictions, nil
+}
+
+// BackfillPredictions classifies every tweet without a prediction from the current
+// version of the built-in classifier, such as the seeded dataset or every tweet after a
+// new version. It runs at startup; tweets written since are classified as they are written.
+func (s *VibecheckService) BackfillPredictions() (int, error) {
+	return s.ScorePredictions(sentiment.Model)
+}
+
+// botResult compares a player's answer on a gold problem with the built-in classifier's prediction
+func (s *VibecheckService) botResult(tweet *models.Tweet, humanCorrect bool) (*models.BotResult, error) {
+	clf := classifier.Lexicon{}
+	prediction, err := s.storedPrediction(clf, tweet)
+	if err != nil {
+		return nil, err
+	}
+	correct := prediction.Label == tweet.Answer
+	return &models.BotResult{
+		Model:   clf.Name(),
+		Version: clf.Version(),
+		Label:   prediction.Label,
+		Score:   prediction.Score,
+		Correct: correct,
+		Beat:    humanCorrect && !correct,
+	}, nil
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"strconv"
+	"vibecheck/models"
+	"vibecheck/sentiment"
+
+	"github.com/lib/pq"
+)
+
+// QuizOptions narrows down which problems the quiz selector may serve
+type QuizOptions struct {
+	// ViewerID identifies the player or session problems are served to, for no-repeat serving
+	ViewerID string
+	// PlayerID, when set, makes the draw favour problems rated near the player's rating
+	PlayerID string
+	// Labeling draws from tweets without a gold answer instead of the gameplay pool
+	Labeling bool
+	// ModelWrong only draws gold problems the built-in classifier gets wrong
+	ModelWrong bool
+	// Collection, when set, only draws problems from that collection
+	Collection string
+}
+
+// SamplingStratified serves each label with its configured share of draws instead of in
+// proportion to how common it is in the dataset
+const SamplingStratified = "stratified"
+
+// PlayerViewerID identifies an identified player for no-repeat serving
+func PlayerViewerID(playerID string) string {
+	return "player_" + playerID
+}
+
+// SessionViewerID identifies an anonymous session for no-repeat serving
+func SessionViewerID(sessionID string) string {
+	return "session_" + sessionID
+}
+
+// seenKey names the viewer's seen-set, kept apart per pool so exhausting a narrower pool
+// does not reset the others
+func seenKey(opts QuizOptions) string {
+	key := "seen_"
+	switch {
+	case opts.Labeling:
+		key += "labeling_"
+	case opts.ModelWrong:
+		key += "modelwrong_"
+	}
+	if opts.Collection != "" {
+		key += "collection_" + opts.Collection + "_"
+	}
+	return key + opts.ViewerID
+}
+
+// drawProblems picks up to n random problem IDs the viewer has not seen yet. Once the
+// viewer has seen the whole pool their seen-set is reset and problems may repeat.
+func (s *VibecheckService) drawProblems(opts QuizOptions, n int) ([]string, error) {
+	ctx := context.Background()
+
+	var seen []string
+	if opts.ViewerID != "" {
+		var err error
+		seen, err = s.redis.SMembers(ctx, seenKey(opts)).Result()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	target := 0.0
+	if opts.PlayerID != "" {
+		var err error
+		target, err = s.playerRating(opts.PlayerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Today's challenge problems are only served by the challenge
+	daily, err := s.storedDailyIDs(today())
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.sampleProblemIDs(opts, target, slices.Concat(seen, daily), n)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) < n && len(seen) > 0 {
+		// The pool is exhausted, start over without repeating what was just drawn
+		s.redis.Del(ctx, seenKey(opts))
+		more, err := s.sampleProblemIDs(opts, target, slices.Concat(ids, daily), n-len(ids))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, more...)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("no tweets available")
+	}
+
+	s.markSeen(opts, ids)
+	return ids, nil
+}
+
+// sampleProblemIDs picks up to n random eligible problem IDs outside of excluded. Gold
+// problems are stratified by label when configured, so a skewed dataset does not make
+// guessing its most common label a winning strategy.
+func (s *VibecheckService) sampleProblemIDs(opts QuizOptions, target float64, excluded []string, n int) ([]string, error) {
+	if opts.Labeling || s.cfg.Quiz.Sampling != SamplingStratified {
+		return s.queryRandomProblemIDs(opts, "", target, excluded, n)
+	}
+
+	available, err := s.countProblemsByLabel(opts, excluded)
+	if err != nil {
+		return nil, err
+	}
+	allocation := allocateLabels(s.labelWeights(), available, n)
+
+	ids := []string{}
+	for _, label := range models.Labels {
+		if allocation[label] == 0 {
+			continue
+		}
+		drawn, err := s.queryRandomProblemIDs(opts, label, target, excluded, allocation[label])
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, drawn...)
+	}
+	// Labels are drawn one after another, mix them so rounds do not come in runs of one label
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return ids, nil
+}
+
+// labelWeights returns the configured target proportion of each label, uniform when none
+// is configured or the configuration names no label. Labels left out of a configuration
+// are not served.
+func (s *VibecheckService) labelWeights() map[string]float64 {
+	if len(s.cfg.Quiz.LabelWeights) > 0 {
+		return s.cfg.Quiz.LabelWeights
+	}
+	weights := map[string]float64{}
+	for _, label := range models.Labels {
+		weights[label] = 1
+	}
+	return weights
+}
+
+// allocateLabels splits n draws between labels, each draw picking a label at random by
+// weight among those with problems left. Once a label runs out the others share its draws.
+func allocateLabels(weights map[string]float64, available map[string]int, n int) map[string]int {
+	allocation := map[string]int{}
+	for i := 0; i < n; i++ {
+		var eligible []string
+		total := 0.0
+		for _, label := range models.Labels {
+			if allocation[label] < available[label] && weights[label] > 0 {
+				eligible = append(eligible, label)
+				total += weights[label]
+			}
+		}
+		if len(eligible) == 0 {
+			break
+		}
+
+		pick := rand.Float64() * total
+		chosen := eligible[len(eligible)-1]
+		for _, label := range eligible {
+			if pick < weights[label] {
+				chosen = label
+				break
+			}
+			pick -= weights[label]
+		}
+		allocation[chosen]++
+	}
+	return allocation
+}
+
+// problemFilter builds the conditions shared by the quiz queries, for a tweets table aliased t.
+// With a label only gold problems with that answer are eligible.
+func problemFilter(opts QuizOptions, label string, excluded []string) (string, []interface{}) {
+	args := []interface{}{pq.Array(excluded)}
+	where := "t.status = 'published' AND NOT (t.id = ANY($1::uuid[]))"
+	if opts.Labeling {
+		where += " AND t.answer IS NULL"
+	} else {
+		where += " AND t.answer IS NOT NULL"
+	}
+	if label != "" {
+		args = append(args, label)
+		where += " AND t.answer = $" + strconv.Itoa(len(args))
+	}
+	if opts.Collection != "" {
+		args = append(args, opts.Collection)
+		where += " AND t.collection = $" + strconv.Itoa(len(args))
+	}
+	if opts.ModelWrong && !opts.Labeling {
+		args = append(args, sentiment.Model, sentiment.Version)
+		n := len(args)
+		where += " AND EXISTS (SELECT 1 FROM predictions p WHERE p.tweet_id = t.id AND p.model = $" + strconv.Itoa(n-1) +
+			" AND p.version = $" + strconv.Itoa(n) + " AND p.label <> t.answer)"
+	}
+	return where, args
+}
+
+// countProblemsByLabel counts the eligible gold problems outside of excluded per answer
+func (s *VibecheckService) countProblemsByLabel(opts QuizOptions, excluded []string) (map[string]int, error) {
+	where, args := problemFilter(opts, "", excluded)
+	rows, err := s.db.Query("SELECT t.answer, COUNT(*) FROM tweets t WHERE "+where+" GROUP BY t.answer", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var label string
+		var count int
+		if err := rows.Scan(&label, &count); err != nil {
+			return nil, err
+		}
+		counts[label] = count
+	}
+	return counts, rows.Err()
+}
+
+// ratingWindows are how far from the target rating a rated draw looks, widening while too
+// few problems are found; 0 is the whole pool. Bounding the draw keeps it from sorting
+// every eligible problem.
+var ratingWindows = []float64{ratingSpread, 3 * ratingSpread, 0}
+
+// queryRandomProblemIDs picks up to n random eligible problem IDs outside of excluded, with
+// the given answer when label is set. With a target rating, problems closer to it are
+// favoured while keeping some randomness.
+func (s *VibecheckService) queryRandomProblemIDs(opts QuizOptions, label string, target float64, excluded []string, n int) ([]string, error) {
+	if target <= 0 {
+		where, args := problemFilter(opts, label, excluded)
+		args = append(args, n)
+		query := "SELECT t.id FROM tweets t WHERE " + where + " ORDER BY random() LIMIT $" + strconv.Itoa(len(args))
+		return s.queryIDs(query, args...)
+	}
+
+	ids := []string{}
+	for _, window := range ratingWindows {
+		// Problems drawn from a narrower window are not drawn again
+		skip := append(append([]string{}, excluded...), ids...)
+		drawn, err := s.queryRatedProblemIDs(opts, label, target, window, skip, n-len(ids))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, drawn...)
+		if len(ids) >= n {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// queryRatedProblemIDs picks up to n eligible problem IDs rated within window of the target,
+// or anywhere when window is 0, favouring those closer to it. Unrated problems count as
+// rated at the default rating.
+func (s *VibecheckService) queryRatedProblemIDs(opts QuizOptions, label string, target, window float64, excluded []string, n int) ([]string, error) {
+	where, args := problemFilter(opts, label, excluded)
+	args = append(args, target, defaultRating, ratingSpread)
+	k := len(args)
+	rating := "COALESCE(r.rating, $" + strconv.Itoa(k-1) + ")"
+	if window > 0 {
+		args = append(args, window)
+		t, w := "$"+strconv.Itoa(k-2)+"::DOUBLE PRECISION", "$"+strconv.Itoa(len(args))+"::DOUBLE PRECISION"
+		where += " AND " + rating + " BETWEEN " + t + " - " + w + " AND " + t + " + " + w
+	}
+	args = append(args, n)
+	query := "SELECT t.id FROM tweets t LEFT JOIN problem_ratings r ON r.tweet_id = t.id WHERE " + where +
+		" ORDER BY ABS(" + rating + " - $" + strconv.Itoa(k-2) + ") + random() * $" + strconv.Itoa(k) +
+		" LIMIT $" + strconv.Itoa(len(args))
+	return s.queryIDs(query, args...)
+}
+
+// markSeen adds problems to the viewer's seen-set, which expires after the configured reset window
+func (s *VibecheckService) markSeen(opts QuizOptions, ids []string) {
+	if opts.ViewerID == "" || len(ids) == 0 {
+		return
+	}
+	ctx := context.Background()
+	members := make([]interface{}, len(ids))
+	for i, id := range ids {
+		members[i] = id
+	}
+	key := seenKey(opts)
+	s.redis.SAdd(ctx, key, members...)
+	if s.cfg.Quiz.SeenResetWindow > 0 {
+		s.redis.ExpireNX(ctx, key, s.cfg.Quiz.SeenResetWindow)
+	}
+}
+
+// GetCollections lists the collections with published problems and how many each has
+func (s *VibecheckService) GetCollections() ([]models.Collection, error) {
+	query := `SELECT collection, COUNT(*) FROM tweets
+		WHERE status = $1 AND collection IS NOT NULL AND answer IS NOT NULL
+		GROUP BY collection ORDER BY collection`
+	rows, err := s.db.Query(query, StatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := []models.Collection{}
+	for rows.Next() {
+		var collection models.Collection
+		if err := rows.Scan(&collection.Name, &collection.Problems); err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"database/sql"
+	"math"
+	"vibecheck/models"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultRating = 1200.0
+	playerK       = 32.0
+	problemK      = 16.0
+
+	// Problems are served within roughly this many points of the player's rating
+	ratingSpread = 200.0
+
+	easyBelow = 1100.0
+	hardAbove = 1300.0
+)
+
+// expectedScore is the Elo probability that a player rated a beats an opponent rated b
+func expectedScore(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// updateRatings applies one Elo match between a player and a problem, which the player wins by answering correctly
+func updateRatings(player, problem float64, correct bool) (float64, float64) {
+	outcome := 0.0
+	if correct {
+		outcome = 1
+	}
+	expected := expectedScore(player, problem)
+	return player + playerK*(outcome-expected), problem - problemK*(outcome-expected)
+}
+
+// difficultyLevel buckets a problem rating into a human readable level
+func difficultyLevel(rating float64) string {
+	switch {
+	case rating < easyBelow:
+		return "easy"
+	case rating > hardAbove:
+		return "hard"
+	}
+	return "medium"
+}
+
+// rateAttempt updates the player's and problem's ratings within an attempt's transaction
+func rateAttempt(tx *sql.Tx, playerID, tweetID string, playerRating float64, correct bool) (float64, error) {
+	query := "INSERT INTO problem_ratings (tweet_id, rating) VALUES ($1, $2) ON CONFLICT (tweet_id) DO NOTHING"
+	if _, err := tx.Exec(query, tweetID, defaultRating); err != nil {
+		return 0, err
+	}
+	var problemRating float64
+	query = "SELECT rating FROM problem_ratings WHERE tweet_id = $1 FOR UPDATE"
+	if err := tx.QueryRow(query, tweetID).Scan(&problemRating); err != nil {
+		return 0, err
+	}
+
+	playerRating, problemRating = updateRatings(playerRating, problemRating, correct)
+
+	query = "UPDATE players SET rating = $1 WHERE id = $2"
+	if _, err := tx.Exec(query, playerRating, playerID); err != nil {
+		return 0, err
+	}
+	query = "UPDATE problem_ratings SET rating = $1, attempts = attempts + 1 WHERE tweet_id = $2"
+	if _, err := tx.Exec(query, problemRating, tweetID); err != nil {
+		return 0, err
+	}
+	return playerRating, nil
+}
+
+// playerRating retrieves a player's rating, or the default rating for new players
+func (s *VibecheckService) playerRating(playerID string) (float64, error) {
+	rating := defaultRating
+	err := s.db.QueryRow("SELECT rating FROM players WHERE id = $1", playerID).Scan(&rating)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return rating, nil
+}
+
+// GetDifficulty retrieves the estimated difficulty of a problem
+func (s *VibecheckService) GetDifficulty(tweetID string) (*models.Difficulty, error) {
+	difficulty := &models.Difficulty{Rating: defaultRating}
+	query := "SELECT rating, attempts FROM problem_ratings WHERE tweet_id = $1"
+	err := s.db.QueryRow(query, tweetID).Scan(&difficulty.Rating, &difficulty.Attempts)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	difficulty.Level = difficultyLevel(difficulty.Rating)
+	return difficulty, nil
+}
+
+// GetDifficulties retrieves the estimated difficulty of several problems, keyed by tweet ID.
+// Problems that were never rated get the default difficulty.
+func (s *VibecheckService) GetDifficulties(tweetIDs []string) (map[string]*models.Difficulty, error) {
+	difficulties := map[string]*models.Difficulty{}
+	tweetIDs = validTweetIDs(tweetIDs)
+	for _, id := range tweetIDs {
+		difficulties[id] = &models.Difficulty{Rating: defaultRating, Level: difficultyLevel(defaultRating)}
+	}
+	if len(tweetIDs) == 0 {
+		return difficulties, nil
+	}
+
+	query := "SELECT tweet_id, rating, attempts FROM problem_ratings WHERE tweet_id = ANY($1::uuid[])"
+	rows, err := s.db.Query(query, pq.Array(tweetIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		difficulty := &models.Difficulty{}
+		if err := rows.Scan(&id, &difficulty.Rating, &difficulty.Attempts); err != nil {
+			return nil, err
+		}
+		difficulty.Level = difficultyLevel(difficulty.Rating)
+		difficulties[id] = difficulty
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return difficulties, nil
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"vibecheck/language"
+	"vibecheck/models"
+)
+
+const (
+	// lengthBucketWidth and lengthBuckets shape the text length histogram, in characters;
+	// the last bucket is open-ended
+	lengthBucketWidth = 40
+	lengthBuckets     = 8
+	// hardestItems is how many of the least accurately answered problems the report lists,
+	// among those with at least hardItemMinAttempts attempts
+	hardestItems        = 10
+	hardItemMinAttempts = 5
+	// unlabeled names tweets without a gold answer in the label balance
+	unlabeled = "unlabeled"
+)
+
+func validStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok || status == StatusPending
+}
+
+// GetDatasetReport summarizes the health of the dataset: label balance, hint coverage, text
+// lengths, duplicates, languages and the problems players get wrong most. With a status
+// only tweets in that status are covered.
+func (s *VibecheckService) GetDatasetReport(status string) (*models.DatasetReport, error) {
+	if status != "" && !validStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+
+	// Near duplicates are clustered over the whole dataset
+	clusters, err := s.GetDuplicateClusters(s.cfg.Duplicates.MaxDistance)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{}
+	query := "SELECT id, text, COALESCE(hint, ''), hint_generated, COALESCE(answer, ''), status, COALESCE(text_hash, '') FROM tweets"
+	if status != "" {
+		args = append(args, status)
+		query += " WHERE status = $1"
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.DatasetReport{GeneratedAt: time.Now().UTC(), Status: status, Statuses: map[string]int{}}
+	labels := map[string]int{}
+	languages := map[string]int{}
+	hashes := map[string]int{}
+	inScope := map[string]bool{}
+	histogram := make([]int, lengthBuckets)
+	totalLength := 0
+	for rows.Next() {
+		var id, text, hint, answer, tweetStatus, hash string
+		var hintGenerated bool
+		if err := rows.Scan(&id, &text, &hint, &hintGenerated, &answer, &tweetStatus, &hash); err != nil {
+			return nil, err
+		}
+		inScope[id] = true
+		report.Tweets++
+		report.Statuses[tweetStatus]++
+
+		if answer == "" {
+			answer = unlabeled
+		}
+		labels[answer]++
+
+		if hint != "" {
+			report.Hints.WithHint++
+			if hintGenerated {
+				report.Hints.Generated++
+			} else {
+				report.Hints.Curated++
+			}
+		}
+
+		length := utf8.RuneCountInString(text)
+		totalLength += length
+		histogram[min(length/lengthBucketWidth, lengthBuckets-1)]++
+
+		languages[language.Detect(text)]++
+		if hash != "" {
+			hashes[hash]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, label := range append(append([]string{}, models.Labels...), unlabeled) {
+		report.Labels = append(report.Labels, models.LabelCount{Label: label, Count: labels[label], Share: ratio(labels[label], report.Tweets)})
+	}
+	report.Hints.Coverage = ratio(report.Hints.WithHint, report.Tweets)
+	report.MeanLength = ratioFloat(float64(totalLength), report.Tweets)
+	for i, count := range histogram {
+		bucket := models.LengthBucket{Min: i * lengthBucketWidth, Count: count}
+		if i < lengthBuckets-1 {
+			bucket.Max = (i+1)*lengthBucketWidth - 1
+		}
+		report.LengthHistogram = append(report.LengthHistogram, bucket)
+	}
+
+	report.Languages = []models.LanguageCount{}
+	for lang, count := range languages {
+		report.Languages = append(report.Languages, models.LanguageCount{Language: lang, Count: count, Share: ratio(count, report.Tweets)})
+	}
+	sort.Slice(report.Languages, func(i, j int) bool {
+		if report.Languages[i].Count != report.Languages[j].Count {
+			return report.Languages[i].Count > report.Languages[j].Count
+		}
+		return report.Languages[i].Language < report.Languages[j].Language
+	})
+
+	// Duplicates count the copies beyond the first of each group
+	for _, count := range hashes {
+		report.Duplicates.ExactDuplicates += count - 1
+	}
+	for _, cluster := range clusters {
+		members := 0
+		for _, tweet := range cluster.Tweets {
+			if inScope[tweet.ID] {
+				members++
+			}
+		}
+		if members > 1 {
+			report.Duplicates.Clusters++
+			report.Duplicates.NearDuplicates += members - 1
+		}
+	}
+	report.Duplicates.Rate = ratio(report.Duplicates.NearDuplicates, report.Tweets)
+
+	report.HardestItems, err = s.hardestItems(status)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// hardestItems retrieves the gold problems with the lowest player accuracy
+func (s *VibecheckService) hardestItems(status string) ([]models.HardItem, error) {
+	args := []interface{}{hardItemMinAttempts, hardestItems}
+	query := `SELECT t.id, t.text, t.answer, COUNT(*), COUNT(*) FILTER (WHERE a.correct)
+		FROM attempts a JOIN tweets t ON t.id = a.tweet_id
+		WHERE t.answer IS NOT NULL`
+	if status != "" {
+		args = append(args, status)
+		query += " AND t.status = $3"
+	}
+	query += ` GROUP BY t.id, t.text, t.answer
+		HAVING COUNT(*) >= $1
+		ORDER BY COUNT(*) FILTER (WHERE a.correct)::float / COUNT(*), COUNT(*) DESC, t.id
+		LIMIT $2`
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.HardItem{}
+	for rows.Next() {
+		var item models.HardItem
+		var correct int
+		if err := rows.Scan(&item.TweetID, &item.Text, &item.Answer, &item.Attempts, &correct); err != nil {
+			return nil, err
+		}
+		item.Accuracy = ratio(correct, item.Attempts)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// DatasetReportMarkdown renders a dataset report as a Markdown document
+func DatasetReportMarkdown(report *models.DatasetReport) string {
+	var b strings.Builder
+	scope := "all tweets"
+	if report.Status != "" {
+		scope = report.Status + " tweets"
+	}
+	fmt.Fprintf(&b, "# Dataset Report\n\nGenerated %s over %d %s.\n", report.GeneratedAt.Format(time.RFC3339), report.Tweets, scope)
+
+	b.WriteString("\n## Statuses\n\n| Status | Tweets |\n| --- | ---: |\n")
+	statuses := make([]string, 0, len(report.Statuses))
+	for status := range report.Statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "| %s | %d |\n", status, report.Statuses[status])
+	}
+
+	b.WriteString("\n## Label Balance\n\n| Label | Tweets | Share |\n| --- | ---: | ---: |\n")
+	for _, label := range report.Labels {
+		fmt.Fprintf(&b, "| %s | %d | %.1f%% |\n", label.Label, label.Count, label.Share*100)
+	}
+
+	fmt.Fprintf(&b, "\n## Hints\n\n%d tweets have a hint (%.1f%%): %d curated and %d generated.\n",
+		report.Hints.WithHint, report.Hints.Coverage*100, report.Hints.Curated, report.Hints.Generated)
+
+	fmt.Fprintf(&b, "\n## Text Length\n\nMean length is %.1f characters.\n\n| Characters | Tweets |\n| --- | ---: |\n", report.MeanLength)
+	for _, bucket := range report.LengthHistogram {
+		if bucket.Max == 0 {
+			fmt.Fprintf(&b, "| %d+ | %d |\n", bucket.Min, bucket.Count)
+		} else {
+			fmt.Fprintf(&b, "| %d-%d | %d |\n", bucket.Min, bucket.Max, bucket.Count)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Duplicates\n\n%d exact duplicates and %d near duplicates in %d clusters (%.1f%% of tweets).\n",
+		report.Duplicates.ExactDuplicates, report.Duplicates.NearDuplicates, report.Duplicates.Clusters, report.Duplicates.Rate*100)
+
+	b.WriteString("\n## Languages\n\n| Language | Tweets | Share |\n| --- | ---: | ---: |\n")
+	for _, lang := range report.Languages {
+		fmt.Fprintf(&b, "| %s | %d | %.1f%% |\n", lang.Language, lang.Count, lang.Share*100)
+	}
+
+	fmt.Fprintf(&b, "\n## Hardest Items\n\nGold problems with at least %d attempts and the lowest player accuracy.\n\n", hardItemMinAttempts)
+	if len(report.HardestItems) == 0 {
+		b.WriteString("No problem has enough attempts yet.\n")
+		return b.String()
+	}
+	b.WriteString("| Tweet | Text | Answer | Attempts | Accuracy |\n| --- | --- | --- | ---: | ---: |\n")
+	for _, item := range report.HardestItems {
+		fmt.Fprintf(&b, "| %s | %s | %s | %d | %.1f%% |\n", item.TweetID, markdownCell(item.Text), item.Answer, item.Attempts, item.Accuracy*100)
+	}
+	return b.String()
+}
+
+// markdownCell keeps a text on one table row
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+	"vibecheck/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReviewPending   = "pending"
+	ReviewConfirmed = "confirmed"
+	ReviewRelabeled = "relabeled"
+)
+
+var (
+	ErrReviewNotFound    = errors.New("review item not found")
+	ErrReviewDecided     = errors.New("review item already decided")
+	ErrInvalidReviewItem = errors.New("invalid review status or label")
+)
+
+// answerDistribution holds how players answered one gold-labeled tweet
+type answerDistribution struct {
+	gold     string
+	attempts int
+	guesses  map[string]int
+}
+
+// disagreement returns the share of attempts that did not pick the gold label, and the most guessed label
+func (d *answerDistribution) disagreement() (float64, string) {
+	top, topCount := "", 0
+	for _, label := range models.Labels {
+		if d.guesses[label] > topCount {
+			top, topCount = label, d.guesses[label]
+		}
+	}
+	return 1 - ratio(d.guesses[d.gold], d.attempts), top
+}
+
+// ScanDisagreements flags gold-labeled problems whose answers contradict the gold label
+// into the review queue and returns how many were newly flagged. Items already decided by
+// a curator are not flagged again.
+func (s *VibecheckService) ScanDisagreements() (int, error) {
+	query := `SELECT a.tweet_id, t.answer, a.guess, COUNT(*)
+		FROM attempts a JOIN tweets t ON t.id = a.tweet_id
+		WHERE t.answer IS NOT NULL
+		GROUP BY a.tweet_id, t.answer, a.guess`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	distributions := map[string]*answerDistribution{}
+	for rows.Next() {
+		var tweetID, gold, guess string
+		var count int
+		if err := rows.Scan(&tweetID, &gold, &guess, &count); err != nil {
+			return 0, err
+		}
+		d, ok := distributions[tweetID]
+		if !ok {
+			d = &answerDistribution{gold: gold, guesses: map[string]int{}}
+			distributions[tweetID] = d
+		}
+		d.guesses[guess] += count
+		d.attempts += count
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	flagged := 0
+	for tweetID, d := range distributions {
+		if d.attempts < s.cfg.Review.MinAttempts {
+			continue
+		}
+		disagreement, suggested := d.disagreement()
+		if disagreement < s.cfg.Review.Threshold || suggested == d.gold {
+			continue
+		}
+
+		guessesJSON, err := json.Marshal(d.guesses)
+		if err != nil {
+			return flagged, err
+		}
+		query = `INSERT INTO review_queue (tweet_id, gold_label, suggested_label, disagreement, attempts, guesses, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (tweet_id) DO UPDATE SET
+				gold_label = EXCLUDED.gold_label,
+				suggested_label = EXCLUDED.suggested_label,
+				disagreement = EXCLUDED.disagreement,
+				attempts = EXCLUDED.attempts,
+				guesses = EXCLUDED.guesses
+			WHERE review_queue.status = $7
+			RETURNING xmax = 0`
+		tx, err := s.db.Begin()
+		if err != nil {
+			return flagged, err
+		}
+		var inserted bool
+		err = tx.QueryRow(query, tweetID, d.gold, suggested, disagreement, d.attempts, guessesJSON, ReviewPending).Scan(&inserted)
+		if err == sql.ErrNoRows {
+			tx.Rollback()
+			continue
+		}
+		if err != nil {
+			tx.Rollback()
+			return flagged, err
+		}
+		// Items already pending were flagged by an earlier scan
+		if inserted {
+			event := models.TweetFlaggedEvent{
+				TweetID: tweetID,
+				Reason:  FlaggedDisagreement,
+				Review:  &models.FlaggedLabel{GoldLabel: d.gold, SuggestedLabel: suggested, Disagreement: disagreement, Attempts: d.attempts},
+			}
+			if err := recordOutboxEvent(tx, tweetID, OutboxTweetFlagged, event); err != nil {
+				tx.Rollback()
+				return flagged, err
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return flagged, err
+		}
+		if inserted {
+			flagged++
+		}
+	}
+
+	return flagged, nil
+}
+
+// RunDisagreementScanner scans for disagreements at the configured interval until ctx is done
+func (s *VibecheckService) RunDisagreementScanner(ctx context.Context) {
+	if s.cfg.Review.ScanInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.cfg.Review.ScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flagged, err := s.ScanDisagreements()
+			if err != nil {
+				log.Printf("Disagreement scan failed: %v\n", err)
+				continue
+			}
+			log.Printf("Disagreement scan flagged %d problems\n", flagged)
+		}
+	}
+}
+
+const reviewColumns = `r.tweet_id, t.text, r.gold_label, r.suggested_label, r.disagreement, r.attempts, r.guesses, r.status,
+	COALESCE(r.decision_label, ''), COALESCE(r.decided_by, ''), COALESCE(r.decision_note, ''), r.flagged_at, r.decided_at`
+
+func scanReviewItem(row interface{ Scan(...interface{}) error }) (*models.ReviewItem, error) {
+	var item models.ReviewItem
+	var guesses []byte
+	err := row.Scan(&item.TweetID, &item.Text, &item.GoldLabel, &item.SuggestedLabel, &item.Disagreement, &item.Attempts, &guesses, &item.Status,
+		&item.DecisionLabel, &item.DecidedBy, &item.DecisionNote, &item.FlaggedAt, &item.DecidedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(guesses, &item.Guesses); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetReviewQueue retrieves review items with a status, most contested first
+func (s *VibecheckService) GetReviewQueue(status string) ([]models.ReviewItem, error) {
+	if status != ReviewPending && status != ReviewConfirmed && status != ReviewRelabeled {
+		return nil, ErrInvalidReviewItem
+	}
+	query := "SELECT " + reviewColumns + ` FROM review_queue r JOIN tweets t ON t.id = r.tweet_id
+		WHERE r.status = $1 ORDER BY r.disagreement DESC, r.attempts DESC`
+	rows, err := s.db.Query(query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ReviewItem{}
+	for rows.Next() {
+		item, err := scanReviewItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// getReviewItem retrieves a single review item
+func (s *VibecheckService) getReviewItem(tweetID string) (*models.ReviewItem, error) {
+	if _, err := uuid.Parse(tweetID); err != nil {
+		return nil, ErrReviewNotFound
+	}
+	query := "SELECT " + reviewColumns + " FROM review_queue r JOIN tweets t ON t.id = r.tweet_id WHERE r.tweet_id = $1"
+	item, err := scanReviewItem(s.db.QueryRow(query, tweetID))
+	if err == sql.ErrNoRows {
+		return nil, ErrReviewNotFound
+	}
+	return item, err
+}
+
+// ConfirmReviewItem records a curator's decision to keep the gold label of a flagged problem
+func (s *VibecheckService) ConfirmReviewItem(tweetID, curator string, decision *models.ReviewDecision) (*models.ReviewItem, error) {
+	item, err := s.getReviewItem(tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != ReviewPending {
+		return nil, ErrReviewDecided
+	}
+
+	query := `UPDATE review_queue SET status = $1, decision_label = $2, decided_by = NULLIF($3, ''), decision_note = NULLIF($4, ''), decided_at = NOW()
+		WHERE tweet_id = $5 AND status = $6`
+	res, err := s.db.Exec(query, ReviewConfirmed, item.GoldLabel, curator, decision.Note, tweetID, ReviewPending)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrReviewDecided
+	}
+	return s.getReviewItem(tweetID)
+}
+
+// RelabelReviewItem records a curator's decision to change the gold label of a flagged problem
+func (s *VibecheckService) RelabelReviewItem(tweetID, curator string, decision *models.ReviewDecision) (*models.ReviewItem, error) {
+	if !models.IsValidLabel(decision.Label) {
+		return nil, ErrInvalidReviewItem
+	}
+	item, err := s.getReviewItem(tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != ReviewPending {
+		return nil, ErrReviewDecided
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE review_queue SET status = $1, decision_label = $2, decided_by = NULLIF($3, ''), decision_note = NULLIF($4, ''), decided_at = NOW()
+		WHERE tweet_id = $5 AND status = $6`
+	res, err := tx.Exec(query, ReviewRelabeled, decision.Label, curator, decision.Note, tweetID, ReviewPending)
+	if err != nil {
+		return nil, err
+	}
+	// A concurrent decision may have come first, and its gold label must stand
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrReviewDecided
+	}
+	if _, err := tx.Exec("UPDATE tweets SET answer = $1 WHERE id = $2", decision.Label, tweetID); err != nil {
+		return nil, err
+	}
+	if err := recordTweetUpdated(tx, tweetID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Drop the cached tweet so the new gold label is used for answers
+	ctx := context.Background()
+	s.redis.Del(ctx, "tweet_"+tweetID)
+
+	return s.getReviewItem(tweetID)
+}
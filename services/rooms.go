@@ -0,0 +1,604 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"vibecheck/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	RoomLobby    = "lobby"
+	RoomQuestion = "question"
+	RoomReveal   = "reveal"
+	RoomFinished = "finished"
+)
+
+// Room events are broadcast to everyone connected to a room
+const (
+	RoomEventState    = "state"
+	RoomEventJoined   = "player_joined"
+	RoomEventQuestion = "question"
+	RoomEventAnswered = "answered"
+	RoomEventResults  = "results"
+	RoomEventFinished = "finished"
+	RoomEventError    = "error"
+)
+
+const (
+	// Room codes leave out letters and digits that are easily confused when read out loud
+	roomCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	roomCodeLength   = 6
+	roomCodeAttempts = 10
+	// roomUpdateRetries is how many times a room update is retried when another replica changed it first
+	roomUpdateRetries = 5
+)
+
+var (
+	ErrInvalidRoom    = errors.New("invalid room size or countdown")
+	ErrRoomNotFound   = errors.New("room not found")
+	ErrNotRoomHost    = errors.New("only the host can advance the room")
+	ErrNotInRoom      = errors.New("join the room before answering")
+	ErrRoomFinished   = errors.New("room is finished")
+	ErrNoOpenQuestion = errors.New("no question is open in the room")
+
+	// errRoomUnchanged aborts a room update that another replica already made
+	errRoomUnchanged = errors.New("room unchanged")
+)
+
+// roomState is the part of a room kept as JSON in Redis. Players and their scores, and
+// the answers to each problem, are kept under keys of their own so players can join and
+// answer without contending on the room.
+type roomState struct {
+	Code             string             `json:"code"`
+	HostID           string             `json:"hostId"`
+	Status           string             `json:"status"`
+	CountdownSeconds int                `json:"countdownSeconds"`
+	Collection       string             `json:"collection,omitempty"`
+	ProblemIDs       []string           `json:"problemIds"`
+	Position         int                `json:"position"`
+	StartedAt        *time.Time         `json:"startedAt,omitempty"`
+	Deadline         *time.Time         `json:"deadline,omitempty"`
+	LastResult       *models.RoomResult `json:"lastResult,omitempty"`
+	CreatedAt        time.Time          `json:"createdAt"`
+}
+
+// roomAnswer is a player's answer to a room problem, as stored until the problem is revealed
+type roomAnswer struct {
+	Guess     string `json:"guess"`
+	ElapsedMs int64  `json:"elapsedMs"`
+}
+
+func roomKey(code string) string {
+	return "room_" + code
+}
+
+func roomPlayersKey(code string) string {
+	return "room_" + code + "_players"
+}
+
+func roomAnswersKey(code string, position int) string {
+	return "room_" + code + "_answers_" + strconv.Itoa(position)
+}
+
+func roomRevealKey(code string, position int) string {
+	return "room_" + code + "_revealed_" + strconv.Itoa(position)
+}
+
+func roomChannel(code string) string {
+	return "room_" + code + "_events"
+}
+
+// NormalizeRoomCode makes room codes case-insensitive
+func NormalizeRoomCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func newRoomCode() string {
+	code := make([]byte, roomCodeLength)
+	for i := range code {
+		code[i] = roomCodeAlphabet[rand.Intn(len(roomCodeAlphabet))]
+	}
+	return string(code)
+}
+
+// CreateRoom draws the problems of a live session and opens a room for them, hosted by a player
+func (s *VibecheckService) CreateRoom(hostID string, newRoom *models.NewRoom) (*models.Room, error) {
+	if newRoom.Size == 0 {
+		newRoom.Size = s.cfg.Rooms.DefaultSize
+	}
+	if newRoom.CountdownSeconds == 0 {
+		newRoom.CountdownSeconds = s.cfg.Rooms.DefaultCountdown
+	}
+	if newRoom.Size < 1 || newRoom.Size > s.cfg.Rooms.MaxSize ||
+		newRoom.CountdownSeconds < 1 || newRoom.CountdownSeconds > s.cfg.Rooms.MaxCountdown {
+		return nil, ErrInvalidRoom
+	}
+
+	ids, err := s.drawProblems(QuizOptions{Collection: newRoom.Collection}, newRoom.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	state := roomState{
+		HostID:           hostID,
+		Status:           RoomLobby,
+		CountdownSeconds: newRoom.CountdownSeconds,
+		Collection:       newRoom.Collection,
+		ProblemIDs:       ids,
+		Position:         -1,
+		CreatedAt:        time.Now().UTC(),
+	}
+	created := false
+	for i := 0; i < roomCodeAttempts && !created; i++ {
+		state.Code = newRoomCode()
+		stateJSON, err := json.Marshal(state)
+		if err != nil {
+			return nil, err
+		}
+		created, err = s.redis.SetNX(ctx, roomKey(state.Code), stateJSON, s.cfg.Rooms.TTL).Result()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !created {
+		return nil, errors.New("could not allocate a room code")
+	}
+
+	if err := s.redis.ZAddNX(ctx, roomPlayersKey(state.Code), redis.Z{Member: hostID}).Err(); err != nil {
+		return nil, err
+	}
+	s.redis.Expire(ctx, roomPlayersKey(state.Code), s.cfg.Rooms.TTL)
+	return s.GetRoom(state.Code)
+}
+
+// loadRoom retrieves a room's state
+func (s *VibecheckService) loadRoom(ctx context.Context, code string) (*roomState, error) {
+	stateJSON, err := s.redis.Get(ctx, roomKey(code)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state roomState
+	if err := json.Unmarshal(stateJSON, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// updateRoom applies a change to a room's state, retrying when another replica changed
+// the room in the meantime. The change may return errRoomUnchanged to leave the room as is.
+func (s *VibecheckService) updateRoom(ctx context.Context, code string, change func(*roomState) error) (*roomState, error) {
+	return s.updateRoomWith(ctx, code, nil, func(state *roomState, pipe redis.Pipeliner) error {
+		return change(state)
+	})
+}
+
+// updateRoomWith is updateRoom for changes that also write other room keys: the writes the
+// change queues on pipe are applied together with the state, and the change is retried
+// when another replica changed the room or one of the watched keys in the meantime.
+func (s *VibecheckService) updateRoomWith(ctx context.Context, code string, watch []string, change func(*roomState, redis.Pipeliner) error) (*roomState, error) {
+	key := roomKey(code)
+	var state *roomState
+	for i := 0; i < roomUpdateRetries; i++ {
+		err := s.redis.Watch(ctx, func(tx *redis.Tx) error {
+			stateJSON, err := tx.Get(ctx, key).Bytes()
+			if err == redis.Nil {
+				return ErrRoomNotFound
+			}
+			if err != nil {
+				return err
+			}
+			state = &roomState{}
+			if err := json.Unmarshal(stateJSON, state); err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				if err := change(state, pipe); err != nil {
+					return err
+				}
+				stateJSON, err := json.Marshal(state)
+				if err != nil {
+					return err
+				}
+				pipe.Set(ctx, key, stateJSON, s.cfg.Rooms.TTL)
+				return nil
+			})
+			return err
+		}, append([]string{key}, watch...)...)
+		if err == redis.TxFailedErr {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+	return nil, redis.TxFailedErr
+}
+
+// roomPlayers lists a room's players by score, and whether they answered the current problem
+func (s *VibecheckService) roomPlayers(ctx context.Context, state *roomState) ([]models.RoomPlayer, error) {
+	scores, err := s.redis.ZRevRangeWithScores(ctx, roomPlayersKey(state.Code), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	answered := map[string]bool{}
+	if state.Status == RoomQuestion || state.Status == RoomReveal {
+		ids, err := s.redis.HKeys(ctx, roomAnswersKey(state.Code, state.Position)).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			answered[id] = true
+		}
+	}
+
+	players := make([]models.RoomPlayer, 0, len(scores))
+	for _, z := range scores {
+		id, _ := z.Member.(string)
+		players = append(players, models.RoomPlayer{PlayerID: id, Score: int(z.Score), Answered: answered[id]})
+	}
+	return players, nil
+}
+
+// GetRoom retrieves a room with its players and, while a problem is open or revealed, that problem
+func (s *VibecheckService) GetRoom(code string) (*models.Room, error) {
+	ctx := context.Background()
+	code = NormalizeRoomCode(code)
+	state, err := s.loadRoom(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if revealed, err := s.revealIfDue(ctx, state); err != nil {
+		return nil, err
+	} else if revealed {
+		if state, err = s.loadRoom(ctx, code); err != nil {
+			return nil, err
+		}
+	}
+	players, err := s.roomPlayers(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	room := &models.Room{
+		Code:             state.Code,
+		HostID:           state.HostID,
+		Status:           state.Status,
+		Size:             len(state.ProblemIDs),
+		CountdownSeconds: state.CountdownSeconds,
+		Collection:       state.Collection,
+		Position:         state.Position,
+		Players:          players,
+		LastResult:       state.LastResult,
+		CreatedAt:        state.CreatedAt,
+	}
+	if state.Status == RoomQuestion || state.Status == RoomReveal {
+		room.Problem, err = s.GetProblem(state.ProblemIDs[state.Position])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if state.Status == RoomQuestion {
+		room.Deadline = state.Deadline
+	}
+	return room, nil
+}
+
+// JoinRoom adds a player to a room that has not finished yet
+func (s *VibecheckService) JoinRoom(playerID, code string) (*models.Room, error) {
+	ctx := context.Background()
+	code = NormalizeRoomCode(code)
+	state, err := s.loadRoom(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if state.Status == RoomFinished {
+		return nil, ErrRoomFinished
+	}
+
+	added, err := s.redis.ZAddNX(ctx, roomPlayersKey(code), redis.Z{Member: playerID}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if added > 0 {
+		s.publishRoomEvent(ctx, &models.RoomEvent{Type: RoomEventJoined, Code: code, PlayerID: playerID, Position: state.Position})
+	}
+	return s.GetRoom(code)
+}
+
+// AnswerRoom records a player's answer to the open problem of a room. Answers are scored
+// when the problem is revealed: at the end of the countdown, once every player has
+// answered, or when the host moves on.
+func (s *VibecheckService) AnswerRoom(playerID, code, guess string) error {
+	ctx := context.Background()
+	code = NormalizeRoomCode(code)
+	state, err := s.loadRoom(ctx, code)
+	if err != nil {
+		return err
+	}
+	if revealed, err := s.revealIfDue(ctx, state); err != nil {
+		return err
+	} else if revealed {
+		return ErrNoOpenQuestion
+	}
+	now := time.Now().UTC()
+	if state.Status != RoomQuestion || state.Deadline == nil || now.After(*state.Deadline) {
+		return ErrNoOpenQuestion
+	}
+	if err := s.redis.ZScore(ctx, roomPlayersKey(code), playerID).Err(); err == redis.Nil {
+		return ErrNotInRoom
+	} else if err != nil {
+		return err
+	}
+
+	answerJSON, err := json.Marshal(roomAnswer{Guess: guess, ElapsedMs: now.Sub(*state.StartedAt).Milliseconds()})
+	if err != nil {
+		return err
+	}
+	key := roomAnswersKey(code, state.Position)
+	recorded, err := s.redis.HSetNX(ctx, key, playerID, answerJSON).Result()
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		return ErrAlreadyAnswered
+	}
+	s.redis.Expire(ctx, key, s.cfg.Rooms.TTL)
+
+	answered, err := s.redis.HLen(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	players, err := s.redis.ZCard(ctx, roomPlayersKey(code)).Result()
+	if err != nil {
+		return err
+	}
+	s.publishRoomEvent(ctx, &models.RoomEvent{
+		Type:     RoomEventAnswered,
+		Code:     code,
+		PlayerID: playerID,
+		Position: state.Position,
+		Answered: int(answered),
+		Players:  int(players),
+	})
+	if answered >= players {
+		return s.revealRoom(ctx, code, state.Position)
+	}
+	return nil
+}
+
+// revealIfDue reveals the open problem of a room once its countdown is over, and reports
+// whether it was due. Any replica serving the room reveals it, so a reveal does not depend
+// on the timer of the replica that opened the problem.
+func (s *VibecheckService) revealIfDue(ctx context.Context, state *roomState) (bool, error) {
+	if state.Status != RoomQuestion || state.Deadline == nil || !time.Now().After(*state.Deadline) {
+		return false, nil
+	}
+	return true, s.revealRoom(ctx, state.Code, state.Position)
+}
+
+// revealRoom scores the answers to a room problem and broadcasts the results. The scores,
+// the revealed state and the reveal marker are written together, so only the first call
+// for a problem reveals it, whichever replica it comes from, and a failed call leaves the
+// problem open for the next one.
+func (s *VibecheckService) revealRoom(ctx context.Context, code string, position int) error {
+	revealKey := roomRevealKey(code, position)
+	if revealed, err := s.redis.Exists(ctx, revealKey).Result(); err != nil || revealed > 0 {
+		return err
+	}
+	state, err := s.loadRoom(ctx, code)
+	if err != nil {
+		return err
+	}
+	if state.Status != RoomQuestion || state.Position != position {
+		return nil
+	}
+
+	tweet, err := s.GetTweet(state.ProblemIDs[position])
+	if err != nil {
+		return err
+	}
+	if tweet == nil {
+		return ErrTweetNotFound
+	}
+
+	// Answers and scores are watched so a late answer or a new player retries the reveal
+	answersKey := roomAnswersKey(code, position)
+	playersKey := roomPlayersKey(code)
+	var result *models.RoomResult
+	_, err = s.updateRoomWith(ctx, code, []string{answersKey, playersKey}, func(state *roomState, pipe redis.Pipeliner) error {
+		if state.Status != RoomQuestion || state.Position != position {
+			return errRoomUnchanged
+		}
+		stored, err := s.redis.HGetAll(ctx, answersKey).Result()
+		if err != nil {
+			return err
+		}
+
+		result = &models.RoomResult{
+			Position: position,
+			TweetID:  tweet.ID,
+			Text:     tweet.Text,
+			Answer:   tweet.Answer,
+			Counts:   map[string]int{},
+			Answers:  []models.RoomAnswer{},
+		}
+		for _, label := range models.Labels {
+			result.Counts[label] = 0
+		}
+		points := map[string]int{}
+		for playerID, answerJSON := range stored {
+			var answer roomAnswer
+			if err := json.Unmarshal([]byte(answerJSON), &answer); err != nil {
+				return err
+			}
+			correct := answer.Guess == tweet.Answer
+			points[playerID] = scoreAttempt(correct, 0, time.Duration(answer.ElapsedMs)*time.Millisecond)
+			if points[playerID] > 0 {
+				pipe.ZIncrBy(ctx, playersKey, float64(points[playerID]), playerID)
+			}
+			result.Counts[answer.Guess]++
+			result.Answers = append(result.Answers, models.RoomAnswer{PlayerID: playerID, Guess: answer.Guess, Correct: correct, Points: points[playerID]})
+		}
+		sort.Slice(result.Answers, func(i, j int) bool {
+			if result.Answers[i].Points != result.Answers[j].Points {
+				return result.Answers[i].Points > result.Answers[j].Points
+			}
+			return result.Answers[i].PlayerID < result.Answers[j].PlayerID
+		})
+
+		// Scores are reported as they stand once this problem's points are added
+		result.Scores, err = s.roomPlayers(ctx, state)
+		if err != nil {
+			return err
+		}
+		for i := range result.Scores {
+			result.Scores[i].Score += points[result.Scores[i].PlayerID]
+		}
+		sort.SliceStable(result.Scores, func(i, j int) bool {
+			return result.Scores[i].Score > result.Scores[j].Score
+		})
+
+		state.Status = RoomReveal
+		state.LastResult = result
+		pipe.Set(ctx, revealKey, 1, s.cfg.Rooms.TTL)
+		return nil
+	})
+	if errors.Is(err, errRoomUnchanged) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.publishRoomEvent(ctx, &models.RoomEvent{Type: RoomEventResults, Code: code, Position: position, Result: result})
+	return nil
+}
+
+// NextRoomProblem reveals the open problem of a room if any, then opens the next problem
+// for the countdown, or finishes the room after the last one. Only the host advances a room.
+func (s *VibecheckService) NextRoomProblem(playerID, code string) (*models.Room, error) {
+	ctx := context.Background()
+	code = NormalizeRoomCode(code)
+	state, err := s.loadRoom(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if state.HostID != playerID {
+		return nil, ErrNotRoomHost
+	}
+	if state.Status == RoomFinished {
+		return nil, ErrRoomFinished
+	}
+	if state.Status == RoomQuestion {
+		if err := s.revealRoom(ctx, code, state.Position); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only advance from the position the host saw, so a repeated request does not skip a problem
+	position := state.Position
+	state, err = s.updateRoom(ctx, code, func(state *roomState) error {
+		if state.Position != position || state.Status == RoomFinished {
+			return errRoomUnchanged
+		}
+		if state.Position+1 >= len(state.ProblemIDs) {
+			state.Status = RoomFinished
+			state.StartedAt, state.Deadline = nil, nil
+			return nil
+		}
+		now := time.Now().UTC()
+		deadline := now.Add(time.Duration(state.CountdownSeconds) * time.Second)
+		state.Position++
+		state.Status = RoomQuestion
+		state.StartedAt, state.Deadline = &now, &deadline
+		return nil
+	})
+	if errors.Is(err, errRoomUnchanged) {
+		return s.GetRoom(code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.GetRoom(code)
+	if err != nil {
+		return nil, err
+	}
+	if state.Status == RoomFinished {
+		s.publishRoomEvent(ctx, &models.RoomEvent{Type: RoomEventFinished, Code: code, Position: state.Position, Scores: room.Players})
+		return room, nil
+	}
+
+	s.publishRoomEvent(ctx, &models.RoomEvent{Type: RoomEventQuestion, Code: code, Position: state.Position, Problem: room.Problem, Deadline: room.Deadline})
+	// Reveal at the deadline from here; if this replica goes away first, the next answer
+	// or read of the room on any replica reveals it
+	position = state.Position
+	time.AfterFunc(time.Until(*state.Deadline), func() {
+		if err := s.revealRoom(context.Background(), code, position); err != nil {
+			log.Printf("Failed to reveal problem %d of room %s: %v\n", position, code, err)
+		}
+	})
+	return room, nil
+}
+
+// publishRoomEvent broadcasts an event to everyone connected to a room, on any replica
+func (s *VibecheckService) publishRoomEvent(ctx context.Context, event *models.RoomEvent) {
+	event.At = time.Now().UTC()
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if err := s.redis.Publish(ctx, roomChannel(event.Code), eventJSON).Err(); err != nil {
+		log.Printf("Failed to publish %s event to room %s: %v\n", event.Type, event.Code, err)
+	}
+}
+
+// SubscribeRoom streams the events broadcast to a room as JSON until the context is done
+func (s *VibecheckService) SubscribeRoom(ctx context.Context, code string) (<-chan string, error) {
+	code = NormalizeRoomCode(code)
+	if _, err := s.loadRoom(ctx, code); err != nil {
+		return nil, err
+	}
+	pubsub := s.redis.Subscribe(ctx, roomChannel(code))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	events := make(chan string)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case events <- message.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
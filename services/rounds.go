@@ -0,0 +1,323 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+	"vibecheck/models"
+
+	"github.com/google/uuid"
+)
+
+const recentRounds = 20
+
+var (
+	ErrInvalidRound  = errors.New("invalid round size or time limit")
+	ErrRoundNotFound = errors.New("round not found")
+	ErrRoundClosed   = errors.New("round is closed")
+	ErrRoundExpired  = errors.New("round time limit exceeded")
+	ErrNotInRound    = errors.New("problem is not part of the round")
+)
+
+// StartRound creates a timed round of problems for a player
+func (s *VibecheckService) StartRound(playerID string, newRound *models.NewRound) (*models.Round, error) {
+	if newRound.Size == 0 {
+		newRound.Size = s.cfg.Round.DefaultSize
+	}
+	if newRound.TimeLimitSeconds == 0 {
+		newRound.TimeLimitSeconds = s.cfg.Round.DefaultTimeLimit
+	}
+	if newRound.Size < 1 || newRound.Size > s.cfg.Round.MaxSize ||
+		newRound.TimeLimitSeconds < 1 || newRound.TimeLimitSeconds > s.cfg.Round.MaxTimeLimit {
+		return nil, ErrInvalidRound
+	}
+
+	ids, err := s.drawProblems(QuizOptions{ViewerID: PlayerViewerID(playerID), PlayerID: playerID, Collection: newRound.Collection}, newRound.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	round := &models.Round{
+		ID:               generateNewID(),
+		PlayerID:         playerID,
+		Size:             len(ids),
+		TimeLimitSeconds: newRound.TimeLimitSeconds,
+		StartedAt:        now,
+		EndsAt:           now.Add(time.Duration(newRound.TimeLimitSeconds) * time.Second),
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO rounds (id, player_id, size, time_limit_seconds, started_at, ends_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	_, err = tx.Exec(query, round.ID, playerID, round.Size, round.TimeLimitSeconds, round.StartedAt, round.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		query = "INSERT INTO round_items (round_id, position, tweet_id) VALUES ($1, $2, $3)"
+		if _, err := tx.Exec(query, round.ID, i+1, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	round.Problems, err = s.getProblemsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return round, nil
+}
+
+// getRound loads a player's round, without its problems or summary
+func (s *VibecheckService) getRound(playerID, roundID string) (*models.Round, []byte, error) {
+	if _, err := uuid.Parse(roundID); err != nil {
+		return nil, nil, ErrRoundNotFound
+	}
+	query := `SELECT id, player_id, size, time_limit_seconds, started_at, ends_at, closed_at, score, summary
+		FROM rounds WHERE id = $1 AND player_id = $2`
+	row := s.db.QueryRow(query, roundID, playerID)
+
+	var round models.Round
+	var summary []byte
+	err := row.Scan(&round.ID, &round.PlayerID, &round.Size, &round.TimeLimitSeconds, &round.StartedAt, &round.EndsAt, &round.ClosedAt, &round.Score, &summary)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, ErrRoundNotFound
+		}
+		return nil, nil, err
+	}
+	return &round, summary, nil
+}
+
+// AnswerRound scores a player's answer to one of the problems of an open round
+func (s *VibecheckService) AnswerRound(playerID, roundID string, attempt *models.AttemptSolution) (*models.AttemptResult, error) {
+	round, _, err := s.getRound(playerID, roundID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if round.ClosedAt != nil {
+		return nil, ErrRoundClosed
+	}
+	if now.After(round.EndsAt) {
+		return nil, ErrRoundExpired
+	}
+
+	tweet, err := s.GetTweet(attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tweet == nil {
+		return nil, ErrNotInRound
+	}
+
+	// Time per item runs from the previous answer, or from the start of the round
+	var lastAnswer sql.NullTime
+	query := "SELECT MAX(answered_at) FROM round_items WHERE round_id = $1"
+	if err := s.db.QueryRow(query, roundID).Scan(&lastAnswer); err != nil {
+		return nil, err
+	}
+	since := round.StartedAt
+	if lastAnswer.Valid && lastAnswer.Time.After(since) {
+		since = lastAnswer.Time
+	}
+	elapsed := now.Sub(since)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The item is claimed, scored and marked together, so concurrent answers cannot both
+	// count and a failed scoring leaves the item open
+	query = `UPDATE round_items SET guess = $1, response_ms = $2, answered_at = $3
+		WHERE round_id = $4 AND tweet_id = $5 AND guess IS NULL`
+	res, err := tx.Exec(query, attempt.Guess, elapsed.Milliseconds(), now, roundID, attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		var exists bool
+		query = "SELECT EXISTS (SELECT 1 FROM round_items WHERE round_id = $1 AND tweet_id = $2)"
+		if err := tx.QueryRow(query, roundID, attempt.ID).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrNotInRound
+		}
+		return nil, ErrAlreadyAnswered
+	}
+
+	hintsUsed, _ := s.takeTracking(playerID, tweet.ID)
+	result, err := recordAttemptTx(tx, playerID, tweet, attempt.Guess, hintsUsed, elapsed)
+	if err != nil {
+		return nil, err
+	}
+
+	query = "UPDATE round_items SET correct = $1, points = $2 WHERE round_id = $3 AND tweet_id = $4"
+	if _, err := tx.Exec(query, result.Correct, result.Points, roundID, attempt.ID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.recordLeaderboardScore(playerID, tweet.Collection, result.Points)
+	return result, nil
+}
+
+// buildRoundSummary totals a round's items and lists its mistakes
+func (s *VibecheckService) buildRoundSummary(round *models.Round) (*models.RoundSummary, error) {
+	query := `SELECT i.position, i.tweet_id, t.text, COALESCE(i.guess, ''), COALESCE(t.answer, ''), i.guess IS NOT NULL,
+			COALESCE(i.correct, FALSE), i.points, COALESCE(i.response_ms, 0), i.answered_at
+		FROM round_items i JOIN tweets t ON t.id = i.tweet_id
+		WHERE i.round_id = $1
+		ORDER BY i.position`
+	rows, err := s.db.Query(query, round.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := &models.RoundSummary{Items: []models.RoundItemResult{}, Mistakes: []models.RoundItemResult{}}
+	lastAnswer := round.StartedAt
+	for rows.Next() {
+		var item models.RoundItemResult
+		var answeredAt sql.NullTime
+		if err := rows.Scan(&item.Position, &item.TweetID, &item.Text, &item.Guess, &item.Answer, &item.Answered, &item.Correct, &item.Points, &item.TimeMs, &answeredAt); err != nil {
+			return nil, err
+		}
+		summary.Items = append(summary.Items, item)
+		if item.Answered {
+			summary.Answered++
+			if answeredAt.Valid && answeredAt.Time.After(lastAnswer) {
+				lastAnswer = answeredAt.Time
+			}
+		}
+		if item.Correct {
+			summary.Correct++
+		} else {
+			summary.Mistakes = append(summary.Mistakes, item)
+		}
+		summary.Score += item.Points
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	summary.Accuracy = ratio(summary.Correct, len(summary.Items))
+	summary.DurationMs = lastAnswer.Sub(round.StartedAt).Milliseconds()
+	return summary, nil
+}
+
+// CloseRound closes a player's round and stores its summary. Closing an already closed round returns the stored summary.
+func (s *VibecheckService) CloseRound(playerID, roundID string) (*models.Round, error) {
+	round, summaryJSON, err := s.getRound(playerID, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.ClosedAt != nil {
+		var summary models.RoundSummary
+		if err := json.Unmarshal(summaryJSON, &summary); err != nil {
+			return nil, err
+		}
+		round.Summary = &summary
+		return round, nil
+	}
+
+	summary, err := s.buildRoundSummary(round)
+	if err != nil {
+		return nil, err
+	}
+	summaryJSON, err = json.Marshal(summary)
+	if err != nil {
+		return nil, err
+	}
+
+	closedAt := time.Now().UTC()
+	query := "UPDATE rounds SET closed_at = $1, score = $2, summary = $3 WHERE id = $4 AND closed_at IS NULL"
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(query, closedAt, summary.Score, summaryJSON, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	round.ClosedAt = &closedAt
+	round.Score = summary.Score
+	round.Summary = summary
+	// A concurrent close may have completed the round first
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		if err := recordAggregateEvent(tx, aggregateRound, roundID, OutboxRoundCompleted, round); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return round, nil
+}
+
+// GetRound retrieves a player's round: its problems while open, its summary once closed.
+// Rounds past their time limit are closed on access.
+func (s *VibecheckService) GetRound(playerID, roundID string) (*models.Round, error) {
+	round, _, err := s.getRound(playerID, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.ClosedAt != nil || time.Now().After(round.EndsAt) {
+		return s.CloseRound(playerID, roundID)
+	}
+
+	ids, err := s.queryIDs("SELECT tweet_id FROM round_items WHERE round_id = $1 ORDER BY position", roundID)
+	if err != nil {
+		return nil, err
+	}
+	round.Problems, err = s.getProblemsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	return round, nil
+}
+
+// ListRounds retrieves a player's most recent rounds, without problems or summaries
+func (s *VibecheckService) ListRounds(playerID string) ([]models.Round, error) {
+	query := `SELECT id, player_id, size, time_limit_seconds, started_at, ends_at, closed_at, score
+		FROM rounds WHERE player_id = $1 ORDER BY started_at DESC LIMIT $2`
+	rows, err := s.db.Query(query, playerID, recentRounds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rounds := []models.Round{}
+	for rows.Next() {
+		var round models.Round
+		if err := rows.Scan(&round.ID, &round.PlayerID, &round.Size, &round.TimeLimitSeconds, &round.StartedAt, &round.EndsAt, &round.ClosedAt, &round.Score); err != nil {
+			return nil, err
+		}
+		rounds = append(rounds, round)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rounds, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"encoding/json"
+	"vibecheck/config"
+	"vibecheck/safety"
+)
+
+// newSafetyPipelines builds the content checks run on submitted text and hints. Hints share
+// the blocklists and PII checks but have no length policy.
+func newSafetyPipelines(cfg config.Config) (text *safety.Pipeline, hint *safety.Pipeline) {
+	content := []safety.Check{
+		safety.NewBlocklist(cfg.Safety.Blocklist, safety.SeverityBlock),
+		safety.NewBlocklist(cfg.Safety.ReviewList, safety.SeverityReview),
+		safety.PII{},
+	}
+	text = safety.NewPipeline(content...)
+	text.Add(safety.Length{Min: cfg.Safety.MinLength, Max: cfg.Safety.MaxLength})
+	hint = safety.NewPipeline(content...)
+	return text, hint
+}
+
+// screenSubmission runs the safety checks over a submission before it is stored
+func (s *VibecheckService) screenSubmission(text, hint string) safety.Verdict {
+	verdict := safety.Allow()
+	verdict.Merge("text", s.textSafety.Run(text))
+	if hint != "" {
+		verdict.Merge("hint", s.hintSafety.Run(hint))
+	}
+	return verdict
+}
+
+// safetyFlags encodes the reasons of a verdict sent to review, for moderators to see
+func safetyFlags(verdict safety.Verdict) ([]byte, error) {
+	if verdict.Action != safety.ActionReview {
+		return nil, nil
+	}
+	return json.Marshal(verdict.Reasons)
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+	"vibecheck/models"
+
+	"github.com/lib/pq"
+)
+
+const (
+	basePoints       = 100
+	hintPenalty      = 30
+	minCorrectPoints = 10
+	maxTimeBonus     = 50
+	timeBonusWindow  = 30 * time.Second
+	recentAttempts   = 10
+
+	// How long a served problem and its hint counter are remembered for a player
+	problemTrackingTTL = time.Hour
+)
+
+// scoreAttempt computes the points awarded for an answer. Correct answers earn
+// the base points minus a penalty per hint used, plus a bonus that decays
+// linearly to zero over the time bonus window. Wrong answers earn nothing.
+func scoreAttempt(correct bool, hintsUsed int, elapsed time.Duration) int {
+	if !correct {
+		return 0
+	}
+
+	points := basePoints - hintsUsed*hintPenalty
+	if points < minCorrectPoints {
+		points = minCorrectPoints
+	}
+
+	if elapsed > 0 && elapsed < timeBonusWindow {
+		remaining := float64(timeBonusWindow-elapsed) / float64(timeBonusWindow)
+		points += int(remaining * maxTimeBonus)
+	}
+
+	return points
+}
+
+func servedKey(playerID, tweetID string) string {
+	return "served_" + playerID + "_" + tweetID
+}
+
+func hintsKey(playerID, tweetID string) string {
+	return "hints_" + playerID + "_" + tweetID
+}
+
+// MarkServed records when a problem was first served to a player, used for the time bonus
+func (s *VibecheckService) MarkServed(playerID, tweetID string) {
+	if playerID == "" {
+		return
+	}
+	ctx := context.Background()
+	s.redis.SetNX(ctx, servedKey(playerID, tweetID), time.Now().UnixMilli(), problemTrackingTTL)
+}
+
+// RecordHintUsed counts a hint request by a player for a problem
+func (s *VibecheckService) RecordHintUsed(playerID, tweetID string) {
+	if playerID == "" {
+		return
+	}
+	ctx := context.Background()
+	key := hintsKey(playerID, tweetID)
+	s.redis.Incr(ctx, key)
+	s.redis.Expire(ctx, key, problemTrackingTTL)
+}
+
+// takeTracking returns and clears the hint count and elapsed time tracked for a player's problem
+func (s *VibecheckService) takeTracking(playerID, tweetID string) (int, time.Duration) {
+	if playerID == "" {
+		return 0, 0
+	}
+	ctx := context.Background()
+
+	hintsUsed := 0
+	if hints, err := s.redis.Get(ctx, hintsKey(playerID, tweetID)).Result(); err == nil {
+		hintsUsed, _ = strconv.Atoi(hints)
+	}
+
+	var elapsed time.Duration
+	if served, err := s.redis.Get(ctx, servedKey(playerID, tweetID)).Result(); err == nil {
+		if ms, err := strconv.ParseInt(served, 10, 64); err == nil {
+			elapsed = time.Since(time.UnixMilli(ms))
+		}
+	}
+
+	s.redis.Del(ctx, hintsKey(playerID, tweetID), servedKey(playerID, tweetID))
+	return hintsUsed, elapsed
+}
+
+// SubmitAnswer checks a player's guess, scores it and records the attempt.
+// Anonymous attempts are recorded but do not earn points or streaks.
+// Guesses on tweets without a gold answer are recorded as labeling votes instead.
+func (s *VibecheckService) SubmitAnswer(playerID string, attempt *models.AttemptSolution) (*models.AttemptResult, error) {
+	tweet, err := s.GetTweet(attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tweet == nil || tweet.Status != StatusPublished {
+		return nil, ErrTweetNotFound
+	}
+	if daily, err := s.isDailyProblem(tweet.ID); err != nil {
+		return nil, err
+	} else if daily {
+		return nil, ErrDailyProblem
+	}
+
+	hintsUsed, elapsed := s.takeTracking(playerID, tweet.ID)
+
+	// Guesses on tweets without a gold answer are collected as labeling votes
+	if tweet.Answer == "" {
+		if err := s.recordVote(playerID, tweet.ID, attempt.Guess); err != nil {
+			return nil, err
+		}
+		return &models.AttemptResult{Labeling: true, HintsUsed: hintsUsed, ResponseMs: elapsed.Milliseconds()}, nil
+	}
+
+	result, err := s.recordAttempt(playerID, tweet, attempt.Guess, hintsUsed, elapsed)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Bot {
+		if result.Bot, err = s.botResult(tweet, result.Correct); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// recordAttempt scores a guess at a tweet and stores it, updating the player's score, streaks and rating
+func (s *VibecheckService) recordAttempt(playerID string, tweet *models.Tweet, guess string, hintsUsed int, elapsed time.Duration) (*models.AttemptResult, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := recordAttemptTx(tx, playerID, tweet, guess, hintsUsed, elapsed)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	s.recordLeaderboardScore(playerID, tweet.Collection, result.Points)
+
+	return result, nil
+}
+
+// recordAttemptTx scores and stores a guess in tx, for callers that claim the attempt in
+// the same transaction. The caller records the leaderboard score once tx commits.
+func recordAttemptTx(tx *sql.Tx, playerID string, tweet *models.Tweet, guess string, hintsUsed int, elapsed time.Duration) (*models.AttemptResult, error) {
+	correct := tweet.Answer == guess
+	result := &models.AttemptResult{
+		Correct:    correct,
+		HintsUsed:  hintsUsed,
+		ResponseMs: elapsed.Milliseconds(),
+	}
+	if playerID != "" {
+		result.Points = scoreAttempt(correct, hintsUsed, elapsed)
+	}
+
+	var player sql.NullString
+	if playerID != "" {
+		player = sql.NullString{String: playerID, Valid: true}
+	}
+	var responseMs sql.NullInt64
+	if elapsed > 0 {
+		responseMs = sql.NullInt64{Int64: result.ResponseMs, Valid: true}
+	}
+
+	// Only a player's first attempt at a tweet is scored, so guessing until correct and
+	// answering again earns nothing
+	query := `INSERT INTO attempts (id, player_id, tweet_id, guess, correct, hints_used, response_ms, points, scored)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (player_id, tweet_id) WHERE scored DO NOTHING`
+	res, err := tx.Exec(query, generateNewID(), player, tweet.ID, guess, correct, hintsUsed, responseMs, result.Points, playerID != "")
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		result.Points, result.Repeat = 0, true
+		if _, err := tx.Exec(query, generateNewID(), player, tweet.ID, guess, correct, hintsUsed, responseMs, 0, false); err != nil {
+			return nil, err
+		}
+		query = "SELECT score, current_streak, best_streak, rating FROM players WHERE id = $1"
+		row := tx.QueryRow(query, playerID)
+		if err := row.Scan(&result.TotalScore, &result.CurrentStreak, &result.BestStreak, &result.Rating); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	if playerID != "" {
+		streak := 0
+		if correct {
+			streak = 1
+		}
+		query = `INSERT INTO players (id, score, current_streak, best_streak) VALUES ($1, $2, $3, $3)
+			ON CONFLICT (id) DO UPDATE SET
+				score = players.score + EXCLUDED.score,
+				current_streak = CASE WHEN EXCLUDED.current_streak > 0 THEN players.current_streak + 1 ELSE 0 END,
+				best_streak = GREATEST(players.best_streak, CASE WHEN EXCLUDED.current_streak > 0 THEN players.current_streak + 1 ELSE 0 END)
+			RETURNING score, current_streak, best_streak, rating`
+		var rating float64
+		row := tx.QueryRow(query, playerID, result.Points, streak)
+		if err := row.Scan(&result.TotalScore, &result.CurrentStreak, &result.BestStreak, &rating); err != nil {
+			return nil, err
+		}
+
+		if tweet.Answer != "" {
+			result.Rating, err = rateAttempt(tx, playerID, tweet.ID, rating, correct)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetPlayerStats retrieves totals, per-label accuracy and recent attempts for a player
+func (s *VibecheckService) GetPlayerStats(playerID string) (*models.PlayerStats, error) {
+	stats := &models.PlayerStats{
+		PlayerID:       playerID,
+		LabelAccuracy:  map[string]models.LabelStats{},
+		RecentAttempts: []models.Attempt{},
+	}
+
+	stats.Rating = defaultRating
+	query := "SELECT score, current_streak, best_streak, rating FROM players WHERE id = $1"
+	row := s.db.QueryRow(query, playerID)
+	if err := row.Scan(&stats.TotalScore, &stats.CurrentStreak, &stats.BestStreak, &stats.Rating); err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	// Accuracy is grouped by the gold label of the tweet that was answered
+	query = `SELECT t.answer, COUNT(*), COUNT(*) FILTER (WHERE a.correct), COALESCE(SUM(a.hints_used), 0)
+		FROM attempts a JOIN tweets t ON t.id = a.tweet_id
+		WHERE a.player_id = $1 AND t.answer IS NOT NULL
+		GROUP BY t.answer`
+	rows, err := s.db.Query(query, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var label string
+		var labelStats models.LabelStats
+		var hintsUsed int
+		if err := rows.Scan(&label, &labelStats.Attempts, &labelStats.Correct, &hintsUsed); err != nil {
+			return nil, err
+		}
+		labelStats.Accuracy = ratio(labelStats.Correct, labelStats.Attempts)
+		stats.LabelAccuracy[label] = labelStats
+		stats.Attempts += labelStats.Attempts
+		stats.Correct += labelStats.Correct
+		stats.HintsUsed += hintsUsed
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	stats.Accuracy = ratio(stats.Correct, stats.Attempts)
+
+	query = `SELECT a.id, a.tweet_id, a.guess, COALESCE(t.answer, ''), a.correct, a.hints_used, COALESCE(a.response_ms, 0), a.points, a.created_at
+		FROM attempts a JOIN tweets t ON t.id = a.tweet_id
+		WHERE a.player_id = $1
+		ORDER BY a.created_at DESC LIMIT $2`
+	rows, err = s.db.Query(query, playerID, recentAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attempt models.Attempt
+		if err := rows.Scan(&attempt.ID, &attempt.TweetID, &attempt.Guess, &attempt.Answer, &attempt.Correct, &attempt.HintsUsed, &attempt.ResponseMs, &attempt.Points, &attempt.CreatedAt); err != nil {
+			return nil, err
+		}
+		stats.RecentAttempts = append(stats.RecentAttempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetPlayerAttempts retrieves a player's attempts at several problems, newest first, keyed by tweet ID
+func (s *VibecheckService) GetPlayerAttempts(playerID string, tweetIDs []string) (map[string][]models.Attempt, error) {
+	attempts := map[string][]models.Attempt{}
+	tweetIDs = validTweetIDs(tweetIDs)
+	if playerID == "" || len(tweetIDs) == 0 {
+		return attempts, nil
+	}
+
+	query := `SELECT a.id, a.tweet_id, a.guess, COALESCE(t.answer, ''), a.correct, a.hints_used, COALESCE(a.response_ms, 0), a.points, a.created_at
+		FROM attempts a JOIN tweets t ON t.id = a.tweet_id
+		WHERE a.player_id = $1 AND a.tweet_id = ANY($2::uuid[])
+		ORDER BY a.created_at DESC`
+	rows, err := s.db.Query(query, playerID, pq.Array(tweetIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attempt models.Attempt
+		if err := rows.Scan(&attempt.ID, &attempt.TweetID, &attempt.Guess, &attempt.Answer, &attempt.Correct, &attempt.HintsUsed, &attempt.ResponseMs, &attempt.Points, &attempt.CreatedAt); err != nil {
+			return nil, err
+		}
+		attempts[attempt.TweetID] = append(attempts[attempt.TweetID], attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
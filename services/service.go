@@ -4,29 +4,47 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"errors"
 	"log"
-	"math/rand"
 	"strconv"
+	"vibecheck/classifier"
+	"vibecheck/config"
 	"vibecheck/models"
+	"vibecheck/outbox"
+	"vibecheck/safety"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 )
 
 type VibecheckService struct {
-	db    *sql.DB
-	redis *redis.Client
+	db          *sql.DB
+	redis       *redis.Client
+	cfg         config.Config
+	textSafety  *safety.Pipeline
+	hintSafety  *safety.Pipeline
+	classifiers map[string]classifier.Classifier
+	webhookWake chan struct{}
+	outboxSink  outbox.Sink
 }
 
-func NewVibecheckService(database *sql.DB, redisClient *redis.Client) *VibecheckService {
-	return &VibecheckService{db: database, redis: redisClient}
+func NewVibecheckService(database *sql.DB, redisClient *redis.Client, cfg config.Config) *VibecheckService {
+	textSafety, hintSafety := newSafetyPipelines(cfg)
+	return &VibecheckService{
+		db:          database,
+		redis:       redisClient,
+		cfg:         cfg,
+		textSafety:  textSafety,
+		hintSafety:  hintSafety,
+		classifiers: newClassifiers(cfg),
+		webhookWake: make(chan struct{}, 1),
+		outboxSink:  newOutboxSink(cfg, redisClient),
+	}
 }
 
 // GetAllTweets retrieves all tweets from the database
 func (s *VibecheckService) GetAllTweets() ([]models.Tweet, error) {
-	query := "SELECT id, text, hint, answer FROM tweets"
+	query := "SELECT id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status, publish_at, hint_generated FROM tweets"
 	ctx := context.Background()
 
 	// Try to get the cached result from Redis
@@ -50,7 +68,7 @@ func (s *VibecheckService) GetAllTweets() ([]models.Tweet, error) {
 	var tweets []models.Tweet
 	for rows.Next() {
 		var tweet models.Tweet
-		if err := rows.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer); err != nil {
+		if err := rows.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer, &tweet.Collection, &tweet.Status, &tweet.PublishAt, &tweet.HintGenerated); err != nil {
 			return nil, err
 		}
 		tweets = append(tweets, tweet)
@@ -75,7 +93,7 @@ func (s *VibecheckService) GetTweetsByPage(pageNumber int, listPerPage int) ([]m
 	}
 
 	offset := (pageNumber - 1) * listPerPage
-	query := "SELECT id, text, hint, answer FROM tweets ORDER BY id LIMIT $1 OFFSET $2"
+	query := "SELECT id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status, publish_at, hint_generated FROM tweets ORDER BY id LIMIT $1 OFFSET $2"
 	ctx := context.Background()
 
 	// Try to get the cached result from Redis
@@ -98,7 +116,7 @@ func (s *VibecheckService) GetTweetsByPage(pageNumber int, listPerPage int) ([]m
 	var tweets []models.Tweet
 	for rows.Next() {
 		var tweet models.Tweet
-		if err := rows.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer); err != nil {
+		if err := rows.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer, &tweet.Collection, &tweet.Status, &tweet.PublishAt, &tweet.HintGenerated); err != nil {
 			return nil, err
 		}
 		tweets = append(tweets, tweet)
@@ -118,7 +136,7 @@ func (s *VibecheckService) GetTweetsByPage(pageNumber int, listPerPage int) ([]m
 
 // GetTweet retrieves a tweet by its ID from the database
 func (s *VibecheckService) GetTweet(id string) (*models.Tweet, error) {
-	query := "SELECT id, text, hint, answer FROM tweets WHERE id = $1"
+	query := "SELECT id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status, publish_at, hint_generated FROM tweets WHERE id = $1"
 	ctx := context.Background()
 
 	// Try to get the cached result from Redis
@@ -134,7 +152,7 @@ func (s *VibecheckService) GetTweet(id string) (*models.Tweet, error) {
 	// If cache miss or unmarshal error, query the database
 	row := s.db.QueryRow(query, id)
 	var tweet models.Tweet
-	if err := row.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer); err != nil {
+	if err := row.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer, &tweet.Collection, &tweet.Status, &tweet.PublishAt, &tweet.HintGenerated); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -150,57 +168,211 @@ func (s *VibecheckService) GetTweet(id string) (*models.Tweet, error) {
 	return &tweet, nil
 }
 
-// NewTweet creates a new tweet in the database and caches it in Redis
-func (s *VibecheckService) NewTweet(tweet *models.NewTweet) error {
-	query := "INSERT INTO tweets (id, text, hint, answer) VALUES ($1, $2, $3, $4)"
-	id := generateNewID()
-	_, err := s.db.Exec(query, id, tweet.Text, tweet.Hint, tweet.Answer)
+// GetTweetsByIDs retrieves the tweets with the given IDs, keyed by ID. Unknown IDs are left out.
+func (s *VibecheckService) GetTweetsByIDs(ids []string) (map[string]*models.Tweet, error) {
+	tweets := map[string]*models.Tweet{}
+	ids = validTweetIDs(ids)
+	if len(ids) == 0 {
+		return tweets, nil
+	}
+
+	query := "SELECT id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status, publish_at, hint_generated FROM tweets WHERE id = ANY($1::uuid[])"
+	rows, err := s.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweet models.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer, &tweet.Collection, &tweet.Status, &tweet.PublishAt, &tweet.HintGenerated); err != nil {
+			return nil, err
+		}
+		tweets[tweet.ID] = &tweet
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tweets, nil
+}
+
+// ExportTweets calls fn with every tweet, optionally only those in a status or collection,
+// reading them from the database as fn consumes them. It stops at the first error fn returns.
+func (s *VibecheckService) ExportTweets(ctx context.Context, status, collection string, fn func(*models.Tweet) error) error {
+	query := `SELECT id, text, COALESCE(hint, ''), COALESCE(answer, ''), COALESCE(collection, ''), status, publish_at, hint_generated FROM tweets
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR collection = $2)
+		ORDER BY id`
+	rows, err := s.db.QueryContext(ctx, query, status, collection)
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweet models.Tweet
+		if err := rows.Scan(&tweet.ID, &tweet.Text, &tweet.Hint, &tweet.Answer, &tweet.Collection, &tweet.Status, &tweet.PublishAt, &tweet.HintGenerated); err != nil {
+			return err
+		}
+		if err := fn(&tweet); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// NewTweet creates a new tweet in the database and caches it in Redis. Tweets are published
+// immediately unless created as a draft or scheduled for later. Content failing the safety
+// checks is rejected, and borderline content is held for moderation instead. Exact duplicates
+// of existing tweets are rejected; near duplicates are returned as warnings.
+func (s *VibecheckService) NewTweet(tweet *models.NewTweet) ([]models.DuplicateMatch, error) {
+	if tweet.Status == "" {
+		tweet.Status = StatusPublished
+	}
+	if err := validateInitialStatus(tweet.Status, tweet.PublishAt); err != nil {
+		return nil, err
+	}
+	// Only scheduled tweets keep a publish time
+	if tweet.Status != StatusScheduled {
+		tweet.PublishAt = nil
+	}
+	verdict := s.screenSubmission(tweet.Text, tweet.Hint)
+	if err := verdict.Err(); err != nil {
+		return nil, err
+	}
+	if verdict.Action == safety.ActionReview {
+		tweet.Status = StatusPending
+		tweet.PublishAt = nil
+	}
+	flags, err := safetyFlags(verdict)
+	if err != nil {
+		return nil, err
+	}
+	duplicates, err := s.checkDuplicates(tweet.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO tweets (id, text, hint, answer, collection, status, publish_at, safety_flags, text_hash, simhash, simhash_bands)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9, $10, $11)`
+	id := generateNewID()
+	hash, sim, bands := fingerprintOf(tweet.Text)
+	_, err = tx.Exec(query, id, tweet.Text, tweet.Hint, tweet.Answer, tweet.Collection, tweet.Status, tweet.PublishAt, flags, hash, sim, bands)
+	if err != nil {
+		return nil, duplicateViolation(err)
+	}
+	if err := recordLexiconPrediction(tx, id, tweet.Text); err != nil {
+		return nil, err
+	}
+	created := models.Tweet{ID: id, Text: tweet.Text, Hint: tweet.Hint, Answer: tweet.Answer, Collection: tweet.Collection, Status: tweet.Status, PublishAt: tweet.PublishAt}
+	if err := recordOutboxEvent(tx, id, OutboxTweetCreated, created); err != nil {
+		return nil, err
+	}
+	if err := recordSafetyFlagged(tx, id, verdict); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
 	// Cache the new tweet in Redis
-	tweetJSON, err := json.Marshal(tweet)
+	tweetJSON, err := json.Marshal(created)
 	if err == nil {
 		ctx := context.Background()
 		cacheKey := "tweet_" + id
 		s.redis.Set(ctx, cacheKey, tweetJSON, 0)
 	}
+	s.invalidateProblemLists()
+	if tweet.Status == StatusPublished {
+		s.announcePublished(id)
+	}
 
-	return nil
+	return duplicates, nil
 }
 
-// UpdateTweet updates an existing tweet in the database and updates the cache in Redis
+// UpdateTweet updates an existing tweet in the database and updates the cache in Redis.
+// The status of the tweet is filled in from the database.
 func (s *VibecheckService) UpdateTweet(tweet *models.Tweet) error {
-	query := "UPDATE tweets SET text = $1, hint = $2, answer = $3 WHERE id = $4"
-	_, err := s.db.Exec(query, tweet.Text, tweet.Hint, tweet.Answer, tweet.ID)
+	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
+
+	// A generated hint stays marked as generated only while it is left unchanged, and a known
+	// duplicate stays exempt from the unique text hash only while its text is
+	query := `UPDATE tweets SET text = $1, hint = $2, answer = NULLIF($3, ''), collection = NULLIF($4, ''), text_hash = $5, simhash = $6,
+		simhash_bands = $7, hint_generated = hint_generated AND hint IS NOT DISTINCT FROM $2,
+		known_duplicate = known_duplicate AND text_hash IS NOT DISTINCT FROM $5
+		WHERE id = $8
+		RETURNING status, publish_at, hint_generated`
+	hash, sim, bands := fingerprintOf(tweet.Text)
+	err = tx.QueryRow(query, tweet.Text, tweet.Hint, tweet.Answer, tweet.Collection, hash, sim, bands, tweet.ID).Scan(&tweet.Status, &tweet.PublishAt, &tweet.HintGenerated)
+	if err == sql.ErrNoRows {
+		// Nothing to update
+		return nil
+	}
+	if err != nil {
+		return duplicateViolation(err)
+	}
 
-	// Update the cache in Redis
-	tweetJSON, err := json.Marshal(tweet)
-	if err == nil {
-		ctx := context.Background()
-		cacheKey := "tweet_" + tweet.ID
-		s.redis.Set(ctx, cacheKey, tweetJSON, 0)
+	// Predictions were made on the old text
+	if _, err := tx.Exec("DELETE FROM predictions WHERE tweet_id = $1", tweet.ID); err != nil {
+		return err
+	}
+	if err := recordLexiconPrediction(tx, tweet.ID, tweet.Text); err != nil {
+		return err
+	}
+	if err := recordOutboxEvent(tx, tweet.ID, OutboxTweetUpdated, tweet); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
 	}
 
+	// Update the cache in Redis, dropping it since the status is not part of the update
+	ctx := context.Background()
+	s.redis.Del(ctx, "tweet_"+tweet.ID, "problem_"+tweet.ID)
+	s.invalidateProblemLists()
+
 	return nil
 }
 
 // DeleteTweet deletes a tweet from the database and removes it from the cache in Redis
 func (s *VibecheckService) DeleteTweet(id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := "DELETE FROM tweets WHERE id = $1"
-	_, err := s.db.Exec(query, id)
+	res, err := tx.Exec(query, id)
 	if err != nil {
 		return err
 	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		if err := recordOutboxEvent(tx, id, OutboxTweetDeleted, models.TweetDeletedEvent{TweetID: id}); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
 	// Remove the tweet from the cache in Redis
 	ctx := context.Background()
-	cacheKey := "tweet_" + id
-	s.redis.Del(ctx, cacheKey)
+	s.redis.Del(ctx, "tweet_"+id, "problem_"+id)
+	s.invalidateProblemLists()
 
 	return nil
 }
@@ -209,7 +381,7 @@ func (s *VibecheckService) DeleteTweet(id string) error {
 
 // GetAllProblems retrieves all problems from the database
 func (s *VibecheckService) GetAllProblems() ([]models.Problem, error) {
-	query := "SELECT id, text FROM tweets"
+	query := allProblemsQuery
 	ctx := context.Background()
 
 	// Try to get the cached result from Redis
@@ -256,7 +428,7 @@ func (s *VibecheckService) GetProblemsByPage(pageNumber int, listPerPage int) ([
 	}
 
 	offset := (pageNumber - 1) * listPerPage
-	query := "SELECT id, text FROM tweets ORDER BY id LIMIT $1 OFFSET $2"
+	query := "SELECT id, text FROM tweets WHERE status = 'published' ORDER BY id LIMIT $1 OFFSET $2"
 	ctx := context.Background()
 
 	// Try to get the cached result from Redis
@@ -297,29 +469,56 @@ func (s *VibecheckService) GetProblemsByPage(pageNumber int, listPerPage int) ([
 	return problems, nil
 }
 
-// NewProblem creates a new problem in the database and caches it in Redis
-func (s *VibecheckService) NewProblem(problem *models.NewProblem) error {
-	query := "INSERT INTO tweets (id, text, hint, answer) VALUES ($1, $2, $3, $4)"
-	id := generateNewID()
-	_, err := s.db.Exec(query, id, problem.Text, problem.Hint, problem.Answer)
+// NewProblem submits a new problem, which stays pending until a moderator approves it.
+// Content failing the safety checks is rejected before it reaches the moderation queue,
+// and duplicates are handled as for new tweets.
+func (s *VibecheckService) NewProblem(problem *models.NewProblem) ([]models.DuplicateMatch, error) {
+	verdict := s.screenSubmission(problem.Text, problem.Hint)
+	if err := verdict.Err(); err != nil {
+		return nil, err
+	}
+	flags, err := safetyFlags(verdict)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	duplicates, err := s.checkDuplicates(problem.Text)
+	if err != nil {
+		return nil, err
 	}
 
-	// Cache the new problem in Redis
-	problemJSON, err := json.Marshal(problem)
-	if err == nil {
-		ctx := context.Background()
-		cacheKey := "problem_" + id
-		s.redis.Set(ctx, cacheKey, problemJSON, 0)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	return nil
+	query := `INSERT INTO tweets (id, text, hint, answer, collection, status, safety_flags, text_hash, simhash, simhash_bands)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9, $10)`
+	id := generateNewID()
+	hash, sim, bands := fingerprintOf(problem.Text)
+	_, err = tx.Exec(query, id, problem.Text, problem.Hint, problem.Answer, problem.Collection, StatusPending, flags, hash, sim, bands)
+	if err != nil {
+		return nil, duplicateViolation(err)
+	}
+	if err := recordLexiconPrediction(tx, id, problem.Text); err != nil {
+		return nil, err
+	}
+	created := models.Tweet{ID: id, Text: problem.Text, Hint: problem.Hint, Answer: problem.Answer, Collection: problem.Collection, Status: StatusPending}
+	if err := recordOutboxEvent(tx, id, OutboxTweetCreated, created); err != nil {
+		return nil, err
+	}
+	if err := recordSafetyFlagged(tx, id, verdict); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return duplicates, nil
 }
 
 // GetProblem retrieves a tweet without hint and answer by its ID
 func (s *VibecheckService) GetProblem(id string) (*models.Problem, error) {
-	query := "SELECT id, text FROM tweets WHERE id = $1"
+	query := "SELECT id, text FROM tweets WHERE id = $1 AND status = 'published'"
 	ctx := context.Background()
 
 	// Try to get the cached result from Redis
@@ -337,7 +536,7 @@ func (s *VibecheckService) GetProblem(id string) (*models.Problem, error) {
 	var problem models.Problem
 	if err := row.Scan(&problem.ID, &problem.Text); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("tweet not found")
+			return nil, ErrTweetNotFound
 		}
 		return nil, err
 	}
@@ -351,32 +550,15 @@ func (s *VibecheckService) GetProblem(id string) (*models.Problem, error) {
 	return &problem, nil
 }
 
-// GetRandomProblem retrieves a random tweet without hint and answer
-func (s *VibecheckService) GetRandomProblem() (*models.Problem, error) {
-	// Get the total number of tweets
-	var count int
-	err := s.db.QueryRow("SELECT COUNT(*) FROM tweets").Scan(&count)
+// GetRandomProblem retrieves a random tweet without hint and answer, avoiding problems the viewer has already seen
+func (s *VibecheckService) GetRandomProblem(opts QuizOptions) (*models.Problem, error) {
+	ids, err := s.drawProblems(opts, 1)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if there are any tweets
-	if count == 0 {
-		return nil, errors.New("no tweets available")
-	}
-
-	// Generate a random offset
-	offset := rand.Intn(count)
-
-	// Fetch the tweet at the random offset
-	query := "SELECT id, text FROM tweets LIMIT 1 OFFSET $1"
-	row := s.db.QueryRow(query, offset)
-
-	var problem models.Problem
-	if err := row.Scan(&problem.ID, &problem.Text); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("no tweets available")
-		}
+	problem, err := s.GetProblem(ids[0])
+	if err != nil {
 		return nil, err
 	}
 
@@ -388,7 +570,7 @@ func (s *VibecheckService) GetRandomProblem() (*models.Problem, error) {
 		s.redis.Set(ctx, cacheKey, problemJSON, 0)
 	}
 
-	return &problem, nil
+	return problem, nil
 }
 
 // CheckSolution checks if the user's guess is correct
@@ -398,23 +580,37 @@ func (s *VibecheckService) CheckSolution(attempt *models.AttemptSolution) (bool,
 		return false, err
 	}
 	if tweet == nil {
-		return false, errors.New("tweet not found")
+		return false, ErrTweetNotFound
 	}
 	return tweet.Answer == attempt.Guess, nil
 }
 
-// GetHint retrieves the hint for a specific tweet
+// GetHint retrieves the hint for a specific tweet, generating one for tweets without a hint
 func (s *VibecheckService) GetHint(tweetID string) (string, error) {
 	tweet, err := s.GetTweet(tweetID)
 	if err != nil {
 		return "", err
 	}
-	if tweet == nil {
-		return "", errors.New("tweet not found")
+	if tweet == nil || tweet.Status != StatusPublished {
+		return "", ErrTweetNotFound
+	}
+	if tweet.Hint == "" {
+		return s.generateHint(tweet)
 	}
 	return tweet.Hint, nil
 }
 
+// validTweetIDs drops the IDs that are not UUIDs, which no tweet can have
+func validTweetIDs(ids []string) []string {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	return valid
+}
+
 func generateNewID() string {
 	return uuid.New().String()
 }
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"errors"
+	"vibecheck/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrTweetNotFound = errors.New("tweet not found")
+	ErrNotAnswered   = errors.New("answer this problem before viewing its stats")
+)
+
+// GetProblemStats aggregates every recorded attempt at a problem
+func (s *VibecheckService) GetProblemStats(tweetID string) (*models.ProblemStats, error) {
+	if _, err := uuid.Parse(tweetID); err != nil {
+		return nil, ErrTweetNotFound
+	}
+	var exists bool
+	if err := s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM tweets WHERE id = $1)", tweetID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrTweetNotFound
+	}
+
+	stats := &models.ProblemStats{TweetID: tweetID, Guesses: map[string]int{}}
+	var hinted int
+	query := `SELECT COUNT(*), COUNT(*) FILTER (WHERE correct), COUNT(*) FILTER (WHERE hints_used > 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY response_ms), 0)
+		FROM attempts WHERE tweet_id = $1`
+	row := s.db.QueryRow(query, tweetID)
+	if err := row.Scan(&stats.Attempts, &stats.Correct, &hinted, &stats.MedianResponseMs); err != nil {
+		return nil, err
+	}
+	stats.Accuracy = ratio(stats.Correct, stats.Attempts)
+	stats.HintUsageRate = ratio(hinted, stats.Attempts)
+
+	rows, err := s.db.Query("SELECT guess, COUNT(*) FROM attempts WHERE tweet_id = $1 GROUP BY guess", tweetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var guess string
+		var count int
+		if err := rows.Scan(&guess, &count); err != nil {
+			return nil, err
+		}
+		stats.Guesses[guess] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetProblemStatsBatch aggregates the recorded attempts at several problems, keyed by tweet ID.
+// Unknown tweets are left out.
+func (s *VibecheckService) GetProblemStatsBatch(tweetIDs []string) (map[string]*models.ProblemStats, error) {
+	batch := map[string]*models.ProblemStats{}
+	tweetIDs = validTweetIDs(tweetIDs)
+	if len(tweetIDs) == 0 {
+		return batch, nil
+	}
+
+	query := `SELECT t.id, COUNT(a.id), COUNT(a.id) FILTER (WHERE a.correct), COUNT(a.id) FILTER (WHERE a.hints_used > 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY a.response_ms), 0)
+		FROM tweets t LEFT JOIN attempts a ON a.tweet_id = t.id
+		WHERE t.id = ANY($1::uuid[])
+		GROUP BY t.id`
+	rows, err := s.db.Query(query, pq.Array(tweetIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stats := &models.ProblemStats{Guesses: map[string]int{}}
+		var hinted int
+		if err := rows.Scan(&stats.TweetID, &stats.Attempts, &stats.Correct, &hinted, &stats.MedianResponseMs); err != nil {
+			return nil, err
+		}
+		stats.Accuracy = ratio(stats.Correct, stats.Attempts)
+		stats.HintUsageRate = ratio(hinted, stats.Attempts)
+		batch[stats.TweetID] = stats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = "SELECT tweet_id, guess, COUNT(*) FROM attempts WHERE tweet_id = ANY($1::uuid[]) GROUP BY tweet_id, guess"
+	rows, err = s.db.Query(query, pq.Array(tweetIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID, guess string
+		var count int
+		if err := rows.Scan(&tweetID, &guess, &count); err != nil {
+			return nil, err
+		}
+		if stats, ok := batch[tweetID]; ok {
+			stats.Guesses[guess] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// GetAnsweredProblemStats retrieves a problem's stats for a player who has already answered it
+func (s *VibecheckService) GetAnsweredProblemStats(playerID, tweetID string) (*models.ProblemStats, error) {
+	if _, err := uuid.Parse(tweetID); err != nil {
+		return nil, ErrTweetNotFound
+	}
+	var answered bool
+	query := "SELECT EXISTS (SELECT 1 FROM attempts WHERE tweet_id = $1 AND player_id = $2)"
+	if err := s.db.QueryRow(query, tweetID, playerID).Scan(&answered); err != nil {
+		return nil, err
+	}
+	if !answered {
+		return nil, ErrNotAnswered
+	}
+	return s.GetProblemStats(tweetID)
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"vibecheck/models"
+	"vibecheck/sentiment"
+)
+
+// versusRows compares attempts on gold problems with the classifier's predictions for the
+// same problems, per collection. Without a player ID every attempt is counted.
+func (s *VibecheckService) versusRows(playerID string) ([]models.CollectionVersus, error) {
+	args := []interface{}{sentiment.Model, sentiment.Version}
+	query := `SELECT COALESCE(t.collection, ''),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE a.correct),
+			COUNT(*) FILTER (WHERE p.label = t.answer),
+			COUNT(*) FILTER (WHERE a.correct AND p.label <> t.answer),
+			COUNT(*) FILTER (WHERE NOT a.correct AND p.label = t.answer)
+		FROM attempts a
+		JOIN tweets t ON t.id = a.tweet_id
+		JOIN predictions p ON p.tweet_id = t.id AND p.model = $1 AND p.version = $2
+		WHERE t.answer IS NOT NULL`
+	if playerID != "" {
+		args = append(args, playerID)
+		query += " AND a.player_id = $3"
+	}
+	query += " GROUP BY 1 ORDER BY 1"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := []models.CollectionVersus{}
+	for rows.Next() {
+		var row models.CollectionVersus
+		if err := rows.Scan(&row.Collection, &row.Attempts, &row.HumanCorrect, &row.ModelCorrect, &row.BeatModel, &row.LostToModel); err != nil {
+			return nil, err
+		}
+		row.HumanAccuracy = ratio(row.HumanCorrect, row.Attempts)
+		row.ModelAccuracy = ratio(row.ModelCorrect, row.Attempts)
+		collections = append(collections, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// GetVersus compares human and classifier accuracy on the problems players answered, overall
+// and per collection. With a player ID only that player's answers are compared.
+func (s *VibecheckService) GetVersus(playerID string) (*models.Versus, error) {
+	collections, err := s.versusRows(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	versus := &models.Versus{PlayerID: playerID, Model: sentiment.Model, Version: sentiment.Version, Collections: collections}
+	for _, row := range collections {
+		versus.Overall.Attempts += row.Attempts
+		versus.Overall.HumanCorrect += row.HumanCorrect
+		versus.Overall.ModelCorrect += row.ModelCorrect
+		versus.Overall.BeatModel += row.BeatModel
+		versus.Overall.LostToModel += row.LostToModel
+	}
+	versus.Overall.HumanAccuracy = ratio(versus.Overall.HumanCorrect, versus.Overall.Attempts)
+	versus.Overall.ModelAccuracy = ratio(versus.Overall.ModelCorrect, versus.Overall.Attempts)
+	return versus, nil
+}
@@ -0,0 +1,501 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"vibecheck/models"
+	"vibecheck/outbox"
+	"vibecheck/safety"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Events webhooks can subscribe to
+const (
+	WebhookTweetCreated   = "tweet.created"
+	WebhookTweetUpdated   = "tweet.updated"
+	WebhookTweetDeleted   = "tweet.deleted"
+	WebhookTweetFlagged   = "tweet.flagged"
+	WebhookRoundCompleted = "round.completed"
+)
+
+var webhookEvents = []string{WebhookTweetCreated, WebhookTweetUpdated, WebhookTweetDeleted, WebhookTweetFlagged, WebhookRoundCompleted}
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	webhookDeliveryPage  = 100
+	webhookDispatchBatch = 20
+	webhookSecretBytes   = 32
+)
+
+var (
+	ErrInvalidWebhook        = errors.New("invalid webhook URL or event")
+	ErrPrivateWebhook        = errors.New("webhook URL must point to a public address")
+	ErrWebhookNotFound       = errors.New("webhook not found")
+	ErrDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrInvalidDeliveryStatus = errors.New("invalid delivery status")
+)
+
+func validWebhookEvent(event string) bool {
+	for _, e := range webhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterWebhook adds an endpoint notified of the given events, or of every event when
+// none is given. A signing secret is generated unless one is provided.
+func (s *VibecheckService) RegisterWebhook(newWebhook *models.NewWebhook) (*models.Webhook, error) {
+	target, err := url.Parse(newWebhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, ErrInvalidWebhook
+	}
+	host := strings.ToLower(target.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return nil, ErrPrivateWebhook
+	}
+	if ip := net.ParseIP(host); ip != nil && !publicAddress(ip) {
+		return nil, ErrPrivateWebhook
+	}
+	events := []string{}
+	seen := map[string]bool{}
+	for _, event := range newWebhook.Events {
+		if !validWebhookEvent(event) {
+			return nil, ErrInvalidWebhook
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	secret := newWebhook.Secret
+	if secret == "" {
+		raw := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		secret = hex.EncodeToString(raw)
+	}
+
+	webhook := &models.Webhook{ID: generateNewID(), URL: newWebhook.URL, Events: events, Secret: secret}
+	query := "INSERT INTO webhooks (id, url, secret, events) VALUES ($1, $2, $3, $4) RETURNING created_at"
+	if err := s.db.QueryRow(query, webhook.ID, webhook.URL, secret, pq.Array(events)).Scan(&webhook.CreatedAt); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// ListWebhooks retrieves the registered webhooks, without their secrets
+func (s *VibecheckService) ListWebhooks() ([]models.Webhook, error) {
+	rows, err := s.db.Query("SELECT id, url, events, created_at FROM webhooks ORDER BY created_at")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		var webhook models.Webhook
+		if err := rows.Scan(&webhook.ID, &webhook.URL, pq.Array(&webhook.Events), &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook along with its delivery log
+func (s *VibecheckService) DeleteWebhook(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrWebhookNotFound
+	}
+	res, err := s.db.Exec("DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+const webhookDeliveryColumns = `id, webhook_id, event_id, event, payload, status, attempts,
+	CASE WHEN status = 'pending' THEN next_attempt_at END, response_status, COALESCE(last_error, ''), created_at, delivered_at`
+
+func scanWebhookDelivery(row interface{ Scan(...interface{}) error }) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var responseStatus sql.NullInt64
+	var payload []byte
+	err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.Event, &payload, &delivery.Status, &delivery.Attempts,
+		&delivery.NextAttemptAt, &responseStatus, &delivery.LastError, &delivery.CreatedAt, &delivery.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	if responseStatus.Valid {
+		status := int(responseStatus.Int64)
+		delivery.ResponseStatus = &status
+	}
+	return &delivery, nil
+}
+
+// GetWebhookDeliveries retrieves a webhook's most recent deliveries, optionally only those in a status
+func (s *VibecheckService) GetWebhookDeliveries(webhookID, status string) ([]models.WebhookDelivery, error) {
+	if status != "" && status != DeliveryPending && status != DeliveryDelivered && status != DeliveryFailed {
+		return nil, ErrInvalidDeliveryStatus
+	}
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	var exists bool
+	if err := s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = $1)", webhookID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrWebhookNotFound
+	}
+
+	query := "SELECT " + webhookDeliveryColumns + " FROM webhook_deliveries WHERE webhook_id = $1 AND ($2 = '' OR status = $2) ORDER BY created_at DESC LIMIT $3"
+	rows, err := s.db.Query(query, webhookID, status, webhookDeliveryPage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RedeliverWebhook queues a new delivery of the same event as an earlier one, keeping the
+// earlier delivery in the log
+func (s *VibecheckService) RedeliverWebhook(webhookID, deliveryID string) (*models.WebhookDelivery, error) {
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return nil, ErrDeliveryNotFound
+	}
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		return nil, ErrDeliveryNotFound
+	}
+	query := `INSERT INTO webhook_deliveries (id, webhook_id, event_id, event, payload)
+		SELECT $1, webhook_id, event_id, event, payload FROM webhook_deliveries WHERE id = $2 AND webhook_id = $3
+		RETURNING ` + webhookDeliveryColumns
+	delivery, err := scanWebhookDelivery(s.db.QueryRow(query, generateNewID(), deliveryID, webhookID))
+	if err == sql.ErrNoRows {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.wakeWebhookDispatcher()
+	return delivery, nil
+}
+
+// webhookEvent maps an outbox event to the webhook event it is delivered as, with its data.
+// Status changes are delivered as updates with the tweet; an empty event means none.
+func webhookEvent(tx *sql.Tx, event outbox.Event) (string, interface{}, error) {
+	switch event.Type {
+	case OutboxTweetCreated:
+		return WebhookTweetCreated, event.Payload, nil
+	case OutboxTweetUpdated:
+		return WebhookTweetUpdated, event.Payload, nil
+	case OutboxTweetDeleted:
+		return WebhookTweetDeleted, event.Payload, nil
+	case OutboxTweetFlagged:
+		return WebhookTweetFlagged, event.Payload, nil
+	case OutboxRoundCompleted:
+		return WebhookRoundCompleted, event.Payload, nil
+	case OutboxTweetStatusChanged:
+		tweet, err := tweetInTx(tx, event.AggregateID)
+		if err != nil || tweet == nil {
+			// A tweet deleted since is announced by its own event
+			return "", nil, err
+		}
+		return WebhookTweetUpdated, tweet, nil
+	}
+	return "", nil, nil
+}
+
+// queueWebhookDeliveries queues a delivery of an outbox event to every webhook subscribed
+// to it, in the relay's transaction, and reports whether any was queued. Deliveries keep
+// the outbox event's ID, so receivers can drop duplicates.
+func queueWebhookDeliveries(tx *sql.Tx, event outbox.Event) (bool, error) {
+	name, data, err := webhookEvent(tx, event)
+	if err != nil || name == "" {
+		return false, err
+	}
+	payload := models.WebhookPayload{ID: event.ID, Event: name, CreatedAt: event.CreatedAt.UTC(), Data: data}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return false, err
+	}
+	query := `INSERT INTO webhook_deliveries (id, webhook_id, event_id, event, payload)
+		SELECT gen_random_uuid(), id, $1, $2, $3 FROM webhooks WHERE cardinality(events) = 0 OR $2 = ANY(events)`
+	res, err := tx.Exec(query, event.ID, name, payloadJSON)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// wakeWebhookDispatcher makes this replica's dispatcher look for due deliveries right away
+func (s *VibecheckService) wakeWebhookDispatcher() {
+	select {
+	case s.webhookWake <- struct{}{}:
+	default:
+	}
+}
+
+// publicAddress reports whether webhooks may be sent to ip. Loopback, private and link-local
+// addresses would let whoever registers a webhook reach internal services.
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsUnspecified()
+}
+
+// newWebhookClient creates the client deliveries are sent with. It checks the address it
+// connects to rather than the URL, so a host resolving to an internal address is refused
+// too, and returns redirects as they are, failing the delivery, instead of following them.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+				return fmt.Errorf("refusing to connect to non-public address %s", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// signWebhookPayload signs a payload and the time it is sent with a webhook's secret, so
+// receivers can check where it came from and reject replays
+func signWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff is how long to wait before retrying a delivery that failed attempts times,
+// doubling from the base delay up to the maximum
+func (s *VibecheckService) webhookBackoff(attempts int) time.Duration {
+	delay := s.cfg.Webhooks.BackoffBase
+	for i := 1; i < attempts && delay < s.cfg.Webhooks.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, s.cfg.Webhooks.BackoffMax)
+}
+
+// claimedDelivery is a due delivery claimed by this replica, with where and how to send it
+type claimedDelivery struct {
+	id       string
+	eventID  string
+	event    string
+	payload  []byte
+	attempts int
+	url      string
+	secret   string
+}
+
+// claimDueDeliveries leases due deliveries to this replica so other replicas skip them
+// while they are being sent. A replica that dies mid-delivery leaves them due again once
+// the lease runs out.
+func (s *VibecheckService) claimDueDeliveries() ([]claimedDelivery, error) {
+	lease := 2 * s.cfg.Webhooks.Timeout
+	query := `UPDATE webhook_deliveries d SET next_attempt_at = NOW() + make_interval(secs => $1)
+		FROM webhooks w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.event_id, d.event, d.payload, d.attempts, w.url, w.secret`
+	rows, err := s.db.Query(query, lease.Seconds(), webhookDispatchBatch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []claimedDelivery
+	for rows.Next() {
+		var d claimedDelivery
+		if err := rows.Scan(&d.id, &d.eventID, &d.event, &d.payload, &d.attempts, &d.url, &d.secret); err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// deliverWebhook posts a signed payload to a webhook and records the outcome, scheduling a
+// retry after a failure until the configured number of attempts is reached
+func (s *VibecheckService) deliverWebhook(client *http.Client, d claimedDelivery) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	var responseStatus *int
+	var deliveryErr error
+
+	req, err := http.NewRequest(http.MethodPost, d.url, bytes.NewReader(d.payload))
+	if err != nil {
+		deliveryErr = err
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "vibecheck-webhooks")
+		req.Header.Set("X-Vibecheck-Event", d.event)
+		req.Header.Set("X-Vibecheck-Delivery", d.id)
+		req.Header.Set("X-Vibecheck-Timestamp", timestamp)
+		req.Header.Set("X-Vibecheck-Signature", signWebhookPayload(d.secret, timestamp, d.payload))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			deliveryErr = err
+		} else {
+			resp.Body.Close()
+			responseStatus = &resp.StatusCode
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				deliveryErr = fmt.Errorf("endpoint responded %d", resp.StatusCode)
+			}
+		}
+	}
+
+	attempts := d.attempts + 1
+	if deliveryErr == nil {
+		query := `UPDATE webhook_deliveries SET status = $1, attempts = $2, response_status = $3, last_error = NULL, delivered_at = NOW()
+			WHERE id = $4`
+		_, err := s.db.Exec(query, DeliveryDelivered, attempts, responseStatus, d.id)
+		return err
+	}
+
+	status := DeliveryPending
+	if attempts >= s.cfg.Webhooks.MaxAttempts {
+		status = DeliveryFailed
+	}
+	query := `UPDATE webhook_deliveries SET status = $1, attempts = $2, response_status = $3, last_error = $4,
+		next_attempt_at = NOW() + make_interval(secs => $5)
+		WHERE id = $6`
+	_, err = s.db.Exec(query, status, attempts, responseStatus, deliveryErr.Error(), s.webhookBackoff(attempts).Seconds(), d.id)
+	return err
+}
+
+// dispatchDueDeliveries sends every due delivery, a batch at a time
+func (s *VibecheckService) dispatchDueDeliveries(client *http.Client) (int, error) {
+	sent := 0
+	for {
+		claimed, err := s.claimDueDeliveries()
+		if err != nil {
+			return sent, err
+		}
+
+		var wg sync.WaitGroup
+		for _, d := range claimed {
+			wg.Add(1)
+			go func(d claimedDelivery) {
+				defer wg.Done()
+				if err := s.deliverWebhook(client, d); err != nil {
+					log.Printf("Failed to record webhook delivery %s: %v\n", d.id, err)
+				}
+			}(d)
+		}
+		wg.Wait()
+
+		sent += len(claimed)
+		if len(claimed) < webhookDispatchBatch {
+			return sent, nil
+		}
+	}
+}
+
+// RunWebhookDispatcher sends due webhook deliveries at the configured interval, and as
+// soon as events are queued on this replica, until ctx is done
+func (s *VibecheckService) RunWebhookDispatcher(ctx context.Context) {
+	if s.cfg.Webhooks.PollInterval <= 0 {
+		return
+	}
+	client := newWebhookClient(s.cfg.Webhooks.Timeout)
+	ticker := time.NewTicker(s.cfg.Webhooks.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.webhookWake:
+		}
+		if _, err := s.dispatchDueDeliveries(client); err != nil {
+			log.Printf("Webhook dispatch failed: %v\n", err)
+		}
+	}
+}
+
+// Reasons a tweet is flagged
+const (
+	FlaggedSafety       = "safety"
+	FlaggedDisagreement = "disagreement"
+)
+
+// recordSafetyFlagged records that the safety checks held a submission for moderation
+func recordSafetyFlagged(tx *sql.Tx, id string, verdict safety.Verdict) error {
+	if verdict.Action != safety.ActionReview {
+		return nil
+	}
+	return recordOutboxEvent(tx, id, OutboxTweetFlagged, models.TweetFlaggedEvent{TweetID: id, Reason: FlaggedSafety, Flags: verdict.Reasons})
+}
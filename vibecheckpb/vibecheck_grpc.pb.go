@@ -0,0 +1,543 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: vibecheckpb/vibecheck.proto
+
+package vibecheckpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Vibecheck_ListTweets_FullMethodName     = "/vibecheck.v1.Vibecheck/ListTweets"
+	Vibecheck_GetTweet_FullMethodName       = "/vibecheck.v1.Vibecheck/GetTweet"
+	Vibecheck_CreateTweet_FullMethodName    = "/vibecheck.v1.Vibecheck/CreateTweet"
+	Vibecheck_UpdateTweet_FullMethodName    = "/vibecheck.v1.Vibecheck/UpdateTweet"
+	Vibecheck_DeleteTweet_FullMethodName    = "/vibecheck.v1.Vibecheck/DeleteTweet"
+	Vibecheck_ExportTweets_FullMethodName   = "/vibecheck.v1.Vibecheck/ExportTweets"
+	Vibecheck_ListProblems_FullMethodName   = "/vibecheck.v1.Vibecheck/ListProblems"
+	Vibecheck_GetProblem_FullMethodName     = "/vibecheck.v1.Vibecheck/GetProblem"
+	Vibecheck_CreateProblem_FullMethodName  = "/vibecheck.v1.Vibecheck/CreateProblem"
+	Vibecheck_GetQuizProblem_FullMethodName = "/vibecheck.v1.Vibecheck/GetQuizProblem"
+	Vibecheck_AnswerProblem_FullMethodName  = "/vibecheck.v1.Vibecheck/AnswerProblem"
+	Vibecheck_GetHint_FullMethodName        = "/vibecheck.v1.Vibecheck/GetHint"
+)
+
+// VibecheckClient is the client API for Vibecheck service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VibecheckClient interface {
+	ListTweets(ctx context.Context, in *ListTweetsRequest, opts ...grpc.CallOption) (*ListTweetsResponse, error)
+	GetTweet(ctx context.Context, in *GetTweetRequest, opts ...grpc.CallOption) (*Tweet, error)
+	CreateTweet(ctx context.Context, in *CreateTweetRequest, opts ...grpc.CallOption) (*CreateTweetResponse, error)
+	UpdateTweet(ctx context.Context, in *UpdateTweetRequest, opts ...grpc.CallOption) (*Tweet, error)
+	DeleteTweet(ctx context.Context, in *DeleteTweetRequest, opts ...grpc.CallOption) (*DeleteTweetResponse, error)
+	ExportTweets(ctx context.Context, in *ExportTweetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tweet], error)
+	ListProblems(ctx context.Context, in *ListProblemsRequest, opts ...grpc.CallOption) (*ListProblemsResponse, error)
+	GetProblem(ctx context.Context, in *GetProblemRequest, opts ...grpc.CallOption) (*Problem, error)
+	CreateProblem(ctx context.Context, in *CreateProblemRequest, opts ...grpc.CallOption) (*CreateProblemResponse, error)
+	GetQuizProblem(ctx context.Context, in *GetQuizProblemRequest, opts ...grpc.CallOption) (*Problem, error)
+	AnswerProblem(ctx context.Context, in *AnswerProblemRequest, opts ...grpc.CallOption) (*AnswerProblemResponse, error)
+	GetHint(ctx context.Context, in *GetHintRequest, opts ...grpc.CallOption) (*GetHintResponse, error)
+}
+
+type vibecheckClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVibecheckClient(cc grpc.ClientConnInterface) VibecheckClient {
+	return &vibecheckClient{cc}
+}
+
+func (c *vibecheckClient) ListTweets(ctx context.Context, in *ListTweetsRequest, opts ...grpc.CallOption) (*ListTweetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTweetsResponse)
+	err := c.cc.Invoke(ctx, Vibecheck_ListTweets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) GetTweet(ctx context.Context, in *GetTweetRequest, opts ...grpc.CallOption) (*Tweet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tweet)
+	err := c.cc.Invoke(ctx, Vibecheck_GetTweet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) CreateTweet(ctx context.Context, in *CreateTweetRequest, opts ...grpc.CallOption) (*CreateTweetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTweetResponse)
+	err := c.cc.Invoke(ctx, Vibecheck_CreateTweet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) UpdateTweet(ctx context.Context, in *UpdateTweetRequest, opts ...grpc.CallOption) (*Tweet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tweet)
+	err := c.cc.Invoke(ctx, Vibecheck_UpdateTweet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) DeleteTweet(ctx context.Context, in *DeleteTweetRequest, opts ...grpc.CallOption) (*DeleteTweetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTweetResponse)
+	err := c.cc.Invoke(ctx, Vibecheck_DeleteTweet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) ExportTweets(ctx context.Context, in *ExportTweetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tweet], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Vibecheck_ServiceDesc.Streams[0], Vibecheck_ExportTweets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportTweetsRequest, Tweet]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Vibecheck_ExportTweetsClient = grpc.ServerStreamingClient[Tweet]
+
+func (c *vibecheckClient) ListProblems(ctx context.Context, in *ListProblemsRequest, opts ...grpc.CallOption) (*ListProblemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProblemsResponse)
+	err := c.cc.Invoke(ctx, Vibecheck_ListProblems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) GetProblem(ctx context.Context, in *GetProblemRequest, opts ...grpc.CallOption) (*Problem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Problem)
+	err := c.cc.Invoke(ctx, Vibecheck_GetProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) CreateProblem(ctx context.Context, in *CreateProblemRequest, opts ...grpc.CallOption) (*CreateProblemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateProblemResponse)
+	err := c.cc.Invoke(ctx, Vibecheck_CreateProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) GetQuizProblem(ctx context.Context, in *GetQuizProblemRequest, opts ...grpc.CallOption) (*Problem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Problem)
+	err := c.cc.Invoke(ctx, Vibecheck_GetQuizProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) AnswerProblem(ctx context.Context, in *AnswerProblemRequest, opts ...grpc.CallOption) (*AnswerProblemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnswerProblemResponse)
+	err := c.cc.Invoke(ctx, Vibecheck_AnswerProblem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vibecheckClient) GetHint(ctx context.Context, in *GetHintRequest, opts ...grpc.CallOption) (*GetHintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHintResponse)
+	err := c.cc.Invoke(ctx, Vibecheck_GetHint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VibecheckServer is the server API for Vibecheck service.
+// All implementations must embed UnimplementedVibecheckServer
+// for forward compatibility.
+type VibecheckServer interface {
+	ListTweets(context.Context, *ListTweetsRequest) (*ListTweetsResponse, error)
+	GetTweet(context.Context, *GetTweetRequest) (*Tweet, error)
+	CreateTweet(context.Context, *CreateTweetRequest) (*CreateTweetResponse, error)
+	UpdateTweet(context.Context, *UpdateTweetRequest) (*Tweet, error)
+	DeleteTweet(context.Context, *DeleteTweetRequest) (*DeleteTweetResponse, error)
+	ExportTweets(*ExportTweetsRequest, grpc.ServerStreamingServer[Tweet]) error
+	ListProblems(context.Context, *ListProblemsRequest) (*ListProblemsResponse, error)
+	GetProblem(context.Context, *GetProblemRequest) (*Problem, error)
+	CreateProblem(context.Context, *CreateProblemRequest) (*CreateProblemResponse, error)
+	GetQuizProblem(context.Context, *GetQuizProblemRequest) (*Problem, error)
+	AnswerProblem(context.Context, *AnswerProblemRequest) (*AnswerProblemResponse, error)
+	GetHint(context.Context, *GetHintRequest) (*GetHintResponse, error)
+	mustEmbedUnimplementedVibecheckServer()
+}
+
+// UnimplementedVibecheckServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVibecheckServer struct{}
+
+func (UnimplementedVibecheckServer) ListTweets(context.Context, *ListTweetsRequest) (*ListTweetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTweets not implemented")
+}
+func (UnimplementedVibecheckServer) GetTweet(context.Context, *GetTweetRequest) (*Tweet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTweet not implemented")
+}
+func (UnimplementedVibecheckServer) CreateTweet(context.Context, *CreateTweetRequest) (*CreateTweetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTweet not implemented")
+}
+func (UnimplementedVibecheckServer) UpdateTweet(context.Context, *UpdateTweetRequest) (*Tweet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTweet not implemented")
+}
+func (UnimplementedVibecheckServer) DeleteTweet(context.Context, *DeleteTweetRequest) (*DeleteTweetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTweet not implemented")
+}
+func (UnimplementedVibecheckServer) ExportTweets(*ExportTweetsRequest, grpc.ServerStreamingServer[Tweet]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTweets not implemented")
+}
+func (UnimplementedVibecheckServer) ListProblems(context.Context, *ListProblemsRequest) (*ListProblemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProblems not implemented")
+}
+func (UnimplementedVibecheckServer) GetProblem(context.Context, *GetProblemRequest) (*Problem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProblem not implemented")
+}
+func (UnimplementedVibecheckServer) CreateProblem(context.Context, *CreateProblemRequest) (*CreateProblemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProblem not implemented")
+}
+func (UnimplementedVibecheckServer) GetQuizProblem(context.Context, *GetQuizProblemRequest) (*Problem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuizProblem not implemented")
+}
+func (UnimplementedVibecheckServer) AnswerProblem(context.Context, *AnswerProblemRequest) (*AnswerProblemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerProblem not implemented")
+}
+func (UnimplementedVibecheckServer) GetHint(context.Context, *GetHintRequest) (*GetHintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHint not implemented")
+}
+func (UnimplementedVibecheckServer) mustEmbedUnimplementedVibecheckServer() {}
+func (UnimplementedVibecheckServer) testEmbeddedByValue()                   {}
+
+// UnsafeVibecheckServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VibecheckServer will
+// result in compilation errors.
+type UnsafeVibecheckServer interface {
+	mustEmbedUnimplementedVibecheckServer()
+}
+
+func RegisterVibecheckServer(s grpc.ServiceRegistrar, srv VibecheckServer) {
+	// If the following call pancis, it indicates UnimplementedVibecheckServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Vibecheck_ServiceDesc, srv)
+}
+
+func _Vibecheck_ListTweets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTweetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).ListTweets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_ListTweets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).ListTweets(ctx, req.(*ListTweetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_GetTweet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTweetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).GetTweet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_GetTweet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).GetTweet(ctx, req.(*GetTweetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_CreateTweet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTweetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).CreateTweet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_CreateTweet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).CreateTweet(ctx, req.(*CreateTweetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_UpdateTweet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTweetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).UpdateTweet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_UpdateTweet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).UpdateTweet(ctx, req.(*UpdateTweetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_DeleteTweet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTweetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).DeleteTweet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_DeleteTweet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).DeleteTweet(ctx, req.(*DeleteTweetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_ExportTweets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTweetsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VibecheckServer).ExportTweets(m, &grpc.GenericServerStream[ExportTweetsRequest, Tweet]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Vibecheck_ExportTweetsServer = grpc.ServerStreamingServer[Tweet]
+
+func _Vibecheck_ListProblems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProblemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).ListProblems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_ListProblems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).ListProblems(ctx, req.(*ListProblemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_GetProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProblemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).GetProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_GetProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).GetProblem(ctx, req.(*GetProblemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_CreateProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProblemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).CreateProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_CreateProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).CreateProblem(ctx, req.(*CreateProblemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_GetQuizProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuizProblemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).GetQuizProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_GetQuizProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).GetQuizProblem(ctx, req.(*GetQuizProblemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_AnswerProblem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerProblemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).AnswerProblem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_AnswerProblem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).AnswerProblem(ctx, req.(*AnswerProblemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vibecheck_GetHint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VibecheckServer).GetHint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vibecheck_GetHint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VibecheckServer).GetHint(ctx, req.(*GetHintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Vibecheck_ServiceDesc is the grpc.ServiceDesc for Vibecheck service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Vibecheck_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vibecheck.v1.Vibecheck",
+	HandlerType: (*VibecheckServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTweets",
+			Handler:    _Vibecheck_ListTweets_Handler,
+		},
+		{
+			MethodName: "GetTweet",
+			Handler:    _Vibecheck_GetTweet_Handler,
+		},
+		{
+			MethodName: "CreateTweet",
+			Handler:    _Vibecheck_CreateTweet_Handler,
+		},
+		{
+			MethodName: "UpdateTweet",
+			Handler:    _Vibecheck_UpdateTweet_Handler,
+		},
+		{
+			MethodName: "DeleteTweet",
+			Handler:    _Vibecheck_DeleteTweet_Handler,
+		},
+		{
+			MethodName: "ListProblems",
+			Handler:    _Vibecheck_ListProblems_Handler,
+		},
+		{
+			MethodName: "GetProblem",
+			Handler:    _Vibecheck_GetProblem_Handler,
+		},
+		{
+			MethodName: "CreateProblem",
+			Handler:    _Vibecheck_CreateProblem_Handler,
+		},
+		{
+			MethodName: "GetQuizProblem",
+			Handler:    _Vibecheck_GetQuizProblem_Handler,
+		},
+		{
+			MethodName: "AnswerProblem",
+			Handler:    _Vibecheck_AnswerProblem_Handler,
+		},
+		{
+			MethodName: "GetHint",
+			Handler:    _Vibecheck_GetHint_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportTweets",
+			Handler:       _Vibecheck_ExportTweets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "vibecheckpb/vibecheck.proto",
+}